@@ -55,12 +55,37 @@ export class SymaTreeSitterParser {
         }
     }
 
+    // Numeric value of a number token: precision marks (1.5`20) are
+    // dropped and base-n literals (16^^FF) are converted.
+    parseNumber(text) {
+        const digits = text.replace(/`.*$/, '');
+        // The literal may carry a leading minus, -16^^FF included
+        const based = digits.match(/^(-?)(\d+)\^\^([0-9a-zA-Z]+)(?:\.([0-9a-zA-Z]+))?$/);
+        if (!based) {
+            return Number(digits);
+        }
+        const base = Number(based[2]);
+        const digitValue = ch => parseInt(ch, 36);
+        let value = 0;
+        for (const ch of based[3]) {
+            value = value * base + digitValue(ch);
+        }
+        let scale = 1 / base;
+        for (const ch of based[4] || '') {
+            value += digitValue(ch) * scale;
+            scale /= base;
+        }
+        const valid = [...based[3] + (based[4] || '')].every(ch => digitValue(ch) < base);
+        const sign = based[1] ? -1 : 1;
+        return base >= 2 && base <= 36 && valid ? sign * value : NaN;
+    }
+
     // Convert tree-sitter node to our AST format
     nodeToAST(node) {
         switch (node.type) {
             case 'number':
                 const numStr = this.sourceText.substring(node.startIndex, node.endIndex);
-                const num = Number(numStr);
+                const num = this.parseNumber(numStr);
                 if (!Number.isFinite(num)) {
                     this.die(`Bad number: ${numStr}`, node);
                 }
@@ -69,34 +94,49 @@ export class SymaTreeSitterParser {
             case 'string':
                 // Remove quotes and process escape sequences
                 let str = this.sourceText.substring(node.startIndex + 1, node.endIndex - 1);
-                str = str.replace(/\\n/g, '\n')
+                str = str.replace(/\\u([0-9a-fA-F]{4})/g, (_, hex) => String.fromCharCode(parseInt(hex, 16)))
+                         .replace(/\\n/g, '\n')
                          .replace(/\\r/g, '\r')
                          .replace(/\\t/g, '\t')
                          .replace(/\\"/g, '"')
                          .replace(/\\\\/g, '\\');
                 return Str(str);
 
+            case 'unary_expression': {
+                const operand = node.childForFieldName('operand');
+                const value = this.nodeToAST(operand);
+                if (value.k !== K.Num) {
+                    this.die('Negation is only supported on numbers', node);
+                }
+                const operator = node.childForFieldName('operator');
+                return operator && operator.type === '+' ? value : Num(-value.v);
+            }
+
             case 'symbol':
                 const sym = this.sourceText.substring(node.startIndex, node.endIndex);
                 return Sym(sym);
 
-            case 'var_pattern': {
-                const pattern = this.sourceText.substring(node.startIndex, node.endIndex);
-                if (pattern === '_') {
-                    // Just _ is wildcard
-                    return Call(Sym('Var'), Str('_'));
-                } else {
-                    // Remove trailing underscore to get the name
-                    const name = pattern.slice(0, -1);
-                    return Call(Sym('Var'), Str(name));
-                }
+            case 'blank': {
+                // _ is a wildcard, __ and ___ are wildcard rests
+                const blank = node.child(0).type;
+                return Call(Sym(blank === '_' ? 'Var' : 'VarRest'), Str('_'));
+            }
+
+            case 'pattern': {
+                const nameNode = node.childForFieldName('name');
+                const name = this.sourceText.substring(nameNode.startIndex, nameNode.endIndex);
+                const blank = node.childForFieldName('blank').child(0).type;
+                return Call(Sym(blank === '_' ? 'Var' : 'VarRest'), Str(name));
             }
 
             case 'var_rest_pattern': {
                 const pattern = this.sourceText.substring(node.startIndex, node.endIndex);
-                if (pattern === '...' || pattern === '___') {
-                    // Just ... or ___ is wildcard rest
+                if (pattern === '...' || pattern === '..') {
+                    // Just ... or .. is wildcard rest
                     return Call(Sym('VarRest'), Str('_'));
+                } else if (pattern.endsWith('..') && !pattern.endsWith('...')) {
+                    // name.. is the same rest variable as name...
+                    return Call(Sym('VarRest'), Str(pattern.slice(0, -2)));
                 } else if (pattern.endsWith('...')) {
                     // Remove ... suffix to get the name
                     let name = pattern.slice(0, -3);
@@ -105,10 +145,6 @@ export class SymaTreeSitterParser {
                         name = name.slice(0, -1);
                     }
                     return Call(Sym('VarRest'), Str(name));
-                } else if (pattern.endsWith('___')) {
-                    // Remove ___ suffix to get the name
-                    const name = pattern.slice(0, -3);
-                    return Call(Sym('VarRest'), Str(name));
                 }
                 // Shouldn't reach here if grammar is correct
                 return Sym(pattern);
@@ -132,6 +168,13 @@ export class SymaTreeSitterParser {
                 return Call(headAST, ...args);
             }
 
+            case 'list': {
+                const items = node.namedChildren
+                    .filter(child => child.type !== 'comment')
+                    .map(child => this.nodeToAST(child));
+                return Call(Sym('List'), ...items);
+            }
+
             case 'function_call': {
                 const funcNode = node.childForFieldName('function');
                 if (!funcNode) {
@@ -152,6 +195,43 @@ export class SymaTreeSitterParser {
                 return Call(Sym(funcName), ...args);
             }
 
+            case 'application': {
+                // head[arg1, arg2, ...] - the head can be any expression
+                const headAST = this.nodeToAST(node.childForFieldName('head'));
+                const args = [];
+                // The arguments field also holds the commas between them
+                for (const argNode of node.childrenForFieldName('arguments')) {
+                    if (argNode.type === 'expression') {
+                        args.push(this.nodeToAST(argNode));
+                    }
+                }
+
+                return Call(headAST, ...args);
+            }
+
+            case 'part': {
+                // expr[[i, j, ...]] is Part[expr, i, j, ...]
+                const args = [this.nodeToAST(node.childForFieldName('value'))];
+                // As in application, the field also holds the commas
+                for (const indexNode of node.childrenForFieldName('indices')) {
+                    if (indexNode.type === 'expression') {
+                        args.push(this.nodeToAST(indexNode));
+                    }
+                }
+
+                return Call(Sym('Part'), ...args);
+            }
+
+            case 'implicit_multiply':
+                // 2 x is Times[2, x]
+                return Call(Sym('Times'),
+                    this.nodeToAST(node.childForFieldName('left')),
+                    this.nodeToAST(node.childForFieldName('right')));
+
+            case 'parenthesized_expression':
+                // Parentheses only group, delegate to the inner expression
+                return this.nodeToAST(node.namedChild(0));
+
             case 'expression':
                 // Expression is a wrapper, delegate to its child
                 if (node.childCount > 0) {
@@ -417,7 +497,7 @@ export class SymaTreeSitterParser {
 
         // For simple nodes, get the original text
         if (node.type === 'number' || node.type === 'string' || node.type === 'symbol' ||
-            node.type === 'var_pattern' || node.type === 'var_rest_pattern') {
+            node.type === 'blank' || node.type === 'pattern' || node.type === 'var_rest_pattern') {
             return this.sourceText.substring(node.startIndex, node.endIndex);
         }
 
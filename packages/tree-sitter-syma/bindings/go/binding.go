@@ -7,9 +7,58 @@ package tree_sitter_syma
 // #endif
 import "C"
 
-import "unsafe"
+import (
+	"errors"
+	"unsafe"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
 
 // Get the tree-sitter Language for this grammar.
 func Language() unsafe.Pointer {
 	return unsafe.Pointer(C.tree_sitter_syma())
 }
+
+// Parse parses source with a fresh parser for the Syma grammar.
+//
+// The caller owns the returned tree and must Close it.
+func Parse(source []byte) (*tree_sitter.Tree, error) {
+	return parse(source, nil)
+}
+
+// Reparse parses newSource incrementally, reusing the unchanged parts of old.
+// edit describes how old's source became newSource; it is applied to old
+// first, so old then matches newSource and must not be used with its former
+// source again.
+//
+// old stays owned by the caller. The caller also owns the returned tree and
+// must Close it.
+func Reparse(old *tree_sitter.Tree, edit tree_sitter.InputEdit, newSource []byte) (*tree_sitter.Tree, error) {
+	if old == nil {
+		return nil, errors.New("tree_sitter_syma: reparse of a nil tree")
+	}
+	old.Edit(&edit)
+	return parse(newSource, old)
+}
+
+func parse(source []byte, old *tree_sitter.Tree) (*tree_sitter.Tree, error) {
+	language := tree_sitter.NewLanguage(Language())
+	if language == nil {
+		return nil, errors.New("tree_sitter_syma: could not load the Syma language")
+	}
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	if err := parser.SetLanguage(language); err != nil {
+		return nil, err
+	}
+	tree := parser.Parse(source, old)
+	if tree == nil {
+		return nil, errors.New("tree_sitter_syma: parse did not produce a tree")
+	}
+	return tree, nil
+}
+
+// ParseString is Parse for source held in a string.
+func ParseString(source string) (*tree_sitter.Tree, error) {
+	return Parse([]byte(source))
+}
@@ -1,6 +1,7 @@
 package tree_sitter_syma_test
 
 import (
+	"strings"
 	"testing"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
@@ -13,3 +14,171 @@ func TestCanLoadGrammar(t *testing.T) {
 		t.Errorf("Error loading Syma grammar")
 	}
 }
+
+func TestParse(t *testing.T) {
+	tree, err := tree_sitter_syma.Parse([]byte("{Add 1 2}"))
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if root.Kind() != "source_file" {
+		t.Errorf("root kind = %q, want %q", root.Kind(), "source_file")
+	}
+	if root.HasError() {
+		t.Errorf("unexpected syntax error in %s", root.ToSexp())
+	}
+	want := "(source_file (expression (brace_call head: (expression (symbol)) arguments: (expression (number)) arguments: (expression (number)))))"
+	if got := root.ToSexp(); got != want {
+		t.Errorf("sexp = %s, want %s", got, want)
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString(`Add(1, "two")`)
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	call := tree.RootNode().Child(0).Child(0)
+	if call.Kind() != "function_call" {
+		t.Fatalf("first expression kind = %q, want %q", call.Kind(), "function_call")
+	}
+	if fn := call.ChildByFieldName("function"); fn == nil || fn.Kind() != "symbol" {
+		t.Errorf("function field = %v, want a symbol", fn)
+	}
+}
+
+// replace returns source with the bytes in [start, end) replaced by text,
+// and the edit that describes the change. The change must be on the first
+// line, since the edit positions are given as columns of row 0.
+func replace(source []byte, start, end uint, text string) ([]byte, tree_sitter.InputEdit) {
+	edited := append(append(append([]byte{}, source[:start]...), text...), source[end:]...)
+	newEnd := start + uint(len(text))
+	return edited, tree_sitter.InputEdit{
+		StartByte:      start,
+		OldEndByte:     end,
+		NewEndByte:     newEnd,
+		StartPosition:  tree_sitter.Point{Row: 0, Column: start},
+		OldEndPosition: tree_sitter.Point{Row: 0, Column: end},
+		NewEndPosition: tree_sitter.Point{Row: 0, Column: newEnd},
+	}
+}
+
+func TestReparse(t *testing.T) {
+	source := []byte("f[x_] := x + 1; g[1, 2]")
+	old, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer old.Close()
+
+	// Turn `x + 1` into `x * 1`.
+	edited, edit := replace(source, 11, 12, "*")
+	tree, err := tree_sitter_syma.Reparse(old, edit, edited)
+	if err != nil {
+		t.Fatalf("Reparse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	fresh, err := tree_sitter_syma.Parse(edited)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer fresh.Close()
+
+	if got, want := tree.RootNode().ToSexp(), fresh.RootNode().ToSexp(); got != want {
+		t.Errorf("reparsed sexp = %s, want %s", got, want)
+	}
+	if got, want := tree.RootNode().EndByte(), uint(len(edited)); got != want {
+		t.Errorf("reparsed root ends at %d, want %d", got, want)
+	}
+}
+
+// TestReparseBrackets edits inside nested brackets, so the reparse resumes
+// from the scanner's serialized bracket stack.
+func TestReparseBrackets(t *testing.T) {
+	source := []byte("a[[f[x]]] + b[[g[1], 2]]")
+	old, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer old.Close()
+
+	// Turn `g[1]` into `g[h[1]]`.
+	edited, edit := replace(source, 17, 18, "h[1]")
+	tree, err := tree_sitter_syma.Reparse(old, edit, edited)
+	if err != nil {
+		t.Fatalf("Reparse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	fresh, err := tree_sitter_syma.Parse(edited)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer fresh.Close()
+
+	if tree.RootNode().HasError() {
+		t.Errorf("unexpected syntax error in %s", tree.RootNode().ToSexp())
+	}
+	if got, want := tree.RootNode().ToSexp(), fresh.RootNode().ToSexp(); got != want {
+		t.Errorf("reparsed sexp = %s, want %s", got, want)
+	}
+}
+
+func TestReparseNilTree(t *testing.T) {
+	if _, err := tree_sitter_syma.Reparse(nil, tree_sitter.InputEdit{}, []byte("a")); err == nil {
+		t.Error("Reparse of a nil tree succeeded")
+	}
+}
+
+// largeSource is a file of n definitions, one per line.
+func largeSource(n int) []byte {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString("f[x_, y_] := {Plus x y} /. <|a -> 1, b :> g[x]|>; h @@ {x, y}\n")
+	}
+	return []byte(b.String())
+}
+
+func BenchmarkParse(b *testing.B) {
+	source := largeSource(1000)
+	for i := 0; i < b.N; i++ {
+		tree, err := tree_sitter_syma.Parse(source)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tree.Close()
+	}
+}
+
+// BenchmarkReparse changes one character (`1` to `2` in the first line) and
+// reparses against the previous tree; compare with BenchmarkParse.
+func BenchmarkReparse(b *testing.B) {
+	source := largeSource(1000)
+	start := uint(strings.Index(string(source), "1"))
+	edited, edit := replace(source, start, start+1, "2")
+	base, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer base.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		old := base.Clone()
+		b.StartTimer()
+		tree, err := tree_sitter_syma.Reparse(old, edit, edited)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StopTimer()
+		tree.Close()
+		old.Close()
+		b.StartTimer()
+	}
+}
@@ -0,0 +1,142 @@
+package tree_sitter_syma
+
+import (
+	"bytes"
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ParseError describes a syntax error in a tree: an ERROR node holding
+// text the grammar could not place, or a MISSING node the parser inserted
+// to recover, such as a "]" that was never written.
+type ParseError struct {
+	// Start and End are the zero-based row and column span of the error.
+	// A missing node is empty, so they are equal.
+	Start, End tree_sitter.Point
+	// StartByte and EndByte are the same span as byte offsets.
+	StartByte, EndByte uint
+	// Missing reports whether the parser inserted a node that is absent
+	// from the source; Kind then names it.
+	Missing bool
+	// Kind is the node type: "ERROR", or the missing token such as "]".
+	Kind string
+	// Text is the source text of the error node, empty for a missing one.
+	Text string
+	// Line is the full source line the error starts on.
+	Line string
+}
+
+func (e *ParseError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("%d:%d: missing %q", e.Start.Row+1, e.Start.Column+1, e.Kind)
+	}
+	return fmt.Sprintf("%d:%d: syntax error at %q", e.Start.Row+1, e.Start.Column+1, e.Text)
+}
+
+// HasError reports whether tree contains any ERROR or MISSING node. It only
+// looks at the root, so it costs nothing to call before FirstError.
+func HasError(tree *tree_sitter.Tree) bool {
+	return tree != nil && tree.RootNode().HasError()
+}
+
+// FirstError returns the first syntax error in tree in source order, or nil
+// if the tree has none. source must be the buffer the tree was parsed from.
+func FirstError(tree *tree_sitter.Tree, source []byte) *ParseError {
+	errs := collectErrors(tree, source, 1)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs[0]
+}
+
+// Validate parses source and returns every syntax error in it, in source
+// order, for linters and CI checks that report all problems at once. A
+// clean source gives an empty slice. An error nested inside another, such
+// as a MISSING node within an ERROR, is part of the outer one and is not
+// listed again.
+//
+// If source cannot be parsed at all, Validate returns a single ERROR
+// covering the whole of it.
+func Validate(source []byte) []ParseError {
+	tree, err := Parse(source)
+	if err != nil {
+		lastLine := bytes.LastIndexByte(source, '\n') + 1
+		return []ParseError{{
+			End: tree_sitter.Point{
+				Row:    uint(bytes.Count(source, []byte("\n"))),
+				Column: uint(len(source) - lastLine),
+			},
+			EndByte: uint(len(source)),
+			Kind:    "ERROR",
+			Text:    string(source),
+			Line:    lineAt(source, 0),
+		}}
+	}
+	defer tree.Close()
+	return collectErrors(tree, source, -1)
+}
+
+// collectErrors returns the outermost ERROR and MISSING nodes of tree in
+// source order, stopping after limit of them unless limit is negative.
+// Subtrees without errors are skipped without visiting their children.
+func collectErrors(tree *tree_sitter.Tree, source []byte, limit int) []ParseError {
+	errs := []ParseError{}
+	if !HasError(tree) {
+		return errs
+	}
+	Walk(tree, func(node *tree_sitter.Node) bool {
+		if len(errs) == limit || !node.HasError() {
+			return false
+		}
+		if node.IsError() || node.IsMissing() {
+			errs = append(errs, *newParseError(node, source))
+			return false
+		}
+		return true
+	})
+	return errs
+}
+
+// ParseStrict is Parse that also reports the first syntax error, as a
+// *ParseError. The tree is returned either way, and the caller must Close
+// it whenever it is not nil.
+func ParseStrict(source []byte) (*tree_sitter.Tree, error) {
+	tree, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	if parseErr := FirstError(tree, source); parseErr != nil {
+		return tree, parseErr
+	}
+	return tree, nil
+}
+
+func newParseError(node *tree_sitter.Node, source []byte) *ParseError {
+	e := &ParseError{
+		Start:     node.StartPosition(),
+		End:       node.EndPosition(),
+		StartByte: node.StartByte(),
+		EndByte:   node.EndByte(),
+		Missing:   node.IsMissing(),
+		Kind:      node.Kind(),
+	}
+	if !e.Missing {
+		e.Text = NodeText(node, source)
+	}
+	e.Line = lineAt(source, e.StartByte)
+	return e
+}
+
+// lineAt returns the line of source containing offset, without its newline.
+func lineAt(source []byte, offset uint) string {
+	if offset > uint(len(source)) {
+		offset = uint(len(source))
+	}
+	start := bytes.LastIndexByte(source[:offset], '\n') + 1
+	end := bytes.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		return string(source[start:])
+	}
+	return string(source[start : int(offset)+end])
+}
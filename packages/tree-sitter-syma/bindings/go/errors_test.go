@@ -0,0 +1,134 @@
+package tree_sitter_syma_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestFirstErrorMissingBracket(t *testing.T) {
+	source := []byte("x = 1\ny = f[g[x]\nz")
+	tree, err := tree_sitter_syma.ParseStrict(source)
+	if tree == nil {
+		t.Fatalf("ParseStrict returned no tree: %v", err)
+	}
+	defer tree.Close()
+
+	var parseErr *tree_sitter_syma.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("ParseStrict error = %v, want a *ParseError", err)
+	}
+	if !parseErr.Missing || parseErr.Kind != "]" {
+		t.Errorf("error = %+v, want a missing \"]\"", parseErr)
+	}
+	if parseErr.Start.Row != 1 || parseErr.Start.Column != 10 {
+		t.Errorf("error at %d:%d, want 1:10", parseErr.Start.Row, parseErr.Start.Column)
+	}
+	if parseErr.Line != "y = f[g[x]" {
+		t.Errorf("error line = %q, want %q", parseErr.Line, "y = f[g[x]")
+	}
+	if got, want := parseErr.Error(), `2:11: missing "]"`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFirstErrorUnexpectedText(t *testing.T) {
+	source := []byte("f[x, , y]")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	if !tree_sitter_syma.HasError(tree) {
+		t.Fatal("HasError = false, want true")
+	}
+	parseErr := tree_sitter_syma.FirstError(tree, source)
+	if parseErr == nil {
+		t.Fatal("FirstError = nil, want an error")
+	}
+	if parseErr.Missing || parseErr.Kind != "ERROR" {
+		t.Errorf("error = %+v, want an ERROR node", parseErr)
+	}
+	if parseErr.Text != "," {
+		t.Errorf("error text = %q, want %q", parseErr.Text, ",")
+	}
+	if parseErr.Start.Row != 0 || parseErr.Start.Column != 3 {
+		t.Errorf("error at %d:%d, want 0:3", parseErr.Start.Row, parseErr.Start.Column)
+	}
+}
+
+func TestNoError(t *testing.T) {
+	source := []byte("f[x_] := x + 1")
+	tree, err := tree_sitter_syma.ParseStrict(source)
+	if err != nil {
+		t.Fatalf("ParseStrict returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	if tree_sitter_syma.HasError(tree) {
+		t.Error("HasError = true, want false")
+	}
+	if parseErr := tree_sitter_syma.FirstError(tree, source); parseErr != nil {
+		t.Errorf("FirstError = %v, want nil", parseErr)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// An unclosed f[ on the first line and a stray ] on the third.
+	source := []byte("x = f[1, 2\ny = 3\nz = g[4]]\n")
+	errs := tree_sitter_syma.Validate(source)
+	if len(errs) != 2 {
+		t.Fatalf("Validate = %v, want 2 errors", errs)
+	}
+
+	if !errs[0].Missing || errs[0].Kind != "]" {
+		t.Errorf("error 0 = %+v, want a missing \"]\"", errs[0])
+	}
+	if errs[0].Start.Row != 0 || errs[0].Start.Column != 10 {
+		t.Errorf("error 0 at %d:%d, want 0:10", errs[0].Start.Row, errs[0].Start.Column)
+	}
+
+	if errs[1].Missing || errs[1].Kind != "ERROR" || errs[1].Text != "]" {
+		t.Errorf("error 1 = %+v, want an ERROR at \"]\"", errs[1])
+	}
+	if errs[1].Start.Row != 2 || errs[1].Start.Column != 8 {
+		t.Errorf("error 1 at %d:%d, want 2:8", errs[1].Start.Row, errs[1].Start.Column)
+	}
+	if errs[1].Line != "z = g[4]]" {
+		t.Errorf("error 1 line = %q, want %q", errs[1].Line, "z = g[4]]")
+	}
+}
+
+func TestValidateClean(t *testing.T) {
+	errs := tree_sitter_syma.Validate([]byte("f[x_] := x + 1\ng[y]"))
+	if errs == nil || len(errs) != 0 {
+		t.Errorf("Validate = %#v, want an empty slice", errs)
+	}
+}
+
+// The standard library is the largest body of real Syma code in the repo,
+// so every file in it must parse without errors.
+func TestValidateStdlib(t *testing.T) {
+	paths, err := filepath.Glob("../../../stdlib/syma/*.syma")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no stdlib files found")
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			source, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, parseErr := range tree_sitter_syma.Validate(source) {
+				t.Errorf("%s: %v: %q", filepath.Base(path), &parseErr, parseErr.Line)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package tree_sitter_syma
+
+import (
+	"fmt"
+	"sort"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	"github.com/tree-sitter/tree-sitter-syma/queries"
+)
+
+// HighlightSpan is a run of source bytes with one highlight capture.
+type HighlightSpan struct {
+	StartByte uint
+	EndByte   uint
+	// Capture is the capture name from highlights.scm without the "@",
+	// such as "function" or "string.escape".
+	Capture string
+}
+
+// Highlight parses source and runs highlights.scm over it, for tools that
+// want to color Syma without handling queries themselves.
+//
+// The spans are sorted by position and never overlap. Where captures nest,
+// the innermost wins over the bytes it covers, so an escape sequence splits
+// its string into three spans. Where several patterns capture the same
+// node, the first one in highlights.scm wins, as in editors. Bytes with no
+// capture, such as whitespace, are not covered by any span. locals.scm is
+// not applied.
+func Highlight(source []byte) ([]HighlightSpan, error) {
+	tree, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	query, queryErr := tree_sitter.NewQuery(tree_sitter.NewLanguage(Language()), queries.Highlights)
+	if queryErr != nil {
+		return nil, fmt.Errorf("tree_sitter_syma: highlights.scm: %w", queryErr)
+	}
+	defer query.Close()
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	// The winning capture of each captured range.
+	type capture struct {
+		start, end uint
+		name       string
+		pattern    uint
+	}
+	best := map[[2]uint]capture{}
+	names := query.CaptureNames()
+	captures := cursor.Captures(query, tree.RootNode(), source)
+	for match, index := captures.Next(); match != nil; match, index = captures.Next() {
+		node := match.Captures[index].Node
+		key := [2]uint{node.StartByte(), node.EndByte()}
+		if old, ok := best[key]; ok && old.pattern <= match.PatternIndex {
+			continue
+		}
+		best[key] = capture{key[0], key[1], names[match.Captures[index].Index], match.PatternIndex}
+	}
+	ordered := make([]capture, 0, len(best))
+	for _, c := range best {
+		if c.start < c.end {
+			ordered = append(ordered, c)
+		}
+	}
+	// Outer ranges before the ranges they contain.
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].start != ordered[j].start {
+			return ordered[i].start < ordered[j].start
+		}
+		return ordered[i].end > ordered[j].end
+	})
+
+	// Sweep the ranges, keeping the enclosing ones on a stack; the top of the
+	// stack owns the bytes up to the next range that starts or ends.
+	var spans []HighlightSpan
+	var stack []capture
+	pos := uint(0)
+	emit := func(end uint) {
+		if len(stack) > 0 && pos < end {
+			spans = append(spans, HighlightSpan{pos, end, stack[len(stack)-1].name})
+		}
+		if pos < end {
+			pos = end
+		}
+	}
+	for _, c := range ordered {
+		for len(stack) > 0 && stack[len(stack)-1].end <= c.start {
+			emit(stack[len(stack)-1].end)
+			stack = stack[:len(stack)-1]
+		}
+		emit(c.start)
+		if len(stack) > 0 && c.end > stack[len(stack)-1].end {
+			c.end = stack[len(stack)-1].end
+		}
+		stack = append(stack, c)
+	}
+	for len(stack) > 0 {
+		emit(stack[len(stack)-1].end)
+		stack = stack[:len(stack)-1]
+	}
+	return spans, nil
+}
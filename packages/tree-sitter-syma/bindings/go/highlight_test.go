@@ -0,0 +1,51 @@
+package tree_sitter_syma_test
+
+import (
+	"testing"
+
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestHighlight(t *testing.T) {
+	spans, err := tree_sitter_syma.Highlight([]byte("f[x] + 1"))
+	if err != nil {
+		t.Fatalf("Highlight returned an error: %v", err)
+	}
+	want := []tree_sitter_syma.HighlightSpan{
+		{StartByte: 0, EndByte: 1, Capture: "function"},
+		{StartByte: 1, EndByte: 2, Capture: "punctuation.bracket"},
+		{StartByte: 2, EndByte: 3, Capture: "variable"},
+		{StartByte: 3, EndByte: 4, Capture: "punctuation.bracket"},
+		{StartByte: 5, EndByte: 6, Capture: "operator"},
+		{StartByte: 7, EndByte: 8, Capture: "number"},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("spans = %v, want %v", spans, want)
+	}
+	for i := range want {
+		if spans[i] != want[i] {
+			t.Errorf("span %d = %v, want %v", i, spans[i], want[i])
+		}
+	}
+}
+
+func TestHighlightInnermostWins(t *testing.T) {
+	// The escape sequence splits the string around it.
+	spans, err := tree_sitter_syma.Highlight([]byte(`"a\nb"`))
+	if err != nil {
+		t.Fatalf("Highlight returned an error: %v", err)
+	}
+	want := []tree_sitter_syma.HighlightSpan{
+		{StartByte: 0, EndByte: 2, Capture: "string"},
+		{StartByte: 2, EndByte: 4, Capture: "string.escape"},
+		{StartByte: 4, EndByte: 6, Capture: "string"},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("spans = %v, want %v", spans, want)
+	}
+	for i := range want {
+		if spans[i] != want[i] {
+			t.Errorf("span %d = %v, want %v", i, spans[i], want[i])
+		}
+	}
+}
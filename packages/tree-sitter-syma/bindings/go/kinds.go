@@ -0,0 +1,146 @@
+package tree_sitter_syma
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// Kind is the grammar type of a named node, as returned by Node.Kind.
+// Comparing against these constants instead of string literals turns a kind
+// that the grammar renames or drops into a compile error; TestKinds keeps
+// the list in step with the grammar.
+type Kind string
+
+// The named node kinds, one per named type in src/node-types.json.
+const (
+	KindAlternatives            Kind = "alternatives"
+	KindAnd                     Kind = "and"
+	KindApplication             Kind = "application"
+	KindApply                   Kind = "apply"
+	KindAssociation             Kind = "association"
+	KindBinaryExpression        Kind = "binary_expression"
+	KindBlank                   Kind = "blank"
+	KindBraceCall               Kind = "brace_call"
+	KindComment                 Kind = "comment"
+	KindComparison              Kind = "comparison"
+	KindComposition             Kind = "composition"
+	KindCompoundAssignment      Kind = "compound_assignment"
+	KindCompoundExpression      Kind = "compound_expression"
+	KindCondition               Kind = "condition"
+	KindDerivative              Kind = "derivative"
+	KindEscapeSequence          Kind = "escape_sequence"
+	KindExpression              Kind = "expression"
+	KindFactorial               Kind = "factorial"
+	KindFunction                Kind = "function"
+	KindFunctionCall            Kind = "function_call"
+	KindImplicitMultiply        Kind = "implicit_multiply"
+	KindInfixApplication        Kind = "infix_application"
+	KindInformation             Kind = "information"
+	KindList                    Kind = "list"
+	KindMapApply                Kind = "map_apply"
+	KindMessageName             Kind = "message_name"
+	KindNamedCharacter          Kind = "named_character"
+	KindNot                     Kind = "not"
+	KindNumber                  Kind = "number"
+	KindOptionalPattern         Kind = "optional_pattern"
+	KindOr                      Kind = "or"
+	KindOutReference            Kind = "out_reference"
+	KindParenthesizedExpression Kind = "parenthesized_expression"
+	KindPart                    Kind = "part"
+	KindPattern                 Kind = "pattern"
+	KindPatternBind             Kind = "pattern_bind"
+	KindPatternDefault          Kind = "pattern_default"
+	KindPatternTest             Kind = "pattern_test"
+	KindPostfixApplication      Kind = "postfix_application"
+	KindPrefixApplication       Kind = "prefix_application"
+	KindRepeated                Kind = "repeated"
+	KindRepeatedNull            Kind = "repeated_null"
+	KindReplaceAll              Kind = "replace_all"
+	KindReplaceRepeated         Kind = "replace_repeated"
+	KindRule                    Kind = "rule"
+	KindRuleDelayed             Kind = "rule_delayed"
+	KindSet                     Kind = "set"
+	KindSetDelayed              Kind = "set_delayed"
+	KindSlot                    Kind = "slot"
+	KindSourceFile              Kind = "source_file"
+	KindSpan                    Kind = "span"
+	KindString                  Kind = "string"
+	KindStringExpression        Kind = "string_expression"
+	KindStringJoin              Kind = "string_join"
+	KindSymbol                  Kind = "symbol"
+	KindUnaryExpression         Kind = "unary_expression"
+	KindVarRestPattern          Kind = "var_rest_pattern"
+)
+
+// KindError is the kind of the nodes that error recovery wraps around text
+// it could not parse. It is not in node-types.json.
+const KindError Kind = "ERROR"
+
+var kinds = []Kind{
+	KindAlternatives,
+	KindAnd,
+	KindApplication,
+	KindApply,
+	KindAssociation,
+	KindBinaryExpression,
+	KindBlank,
+	KindBraceCall,
+	KindComment,
+	KindComparison,
+	KindComposition,
+	KindCompoundAssignment,
+	KindCompoundExpression,
+	KindCondition,
+	KindDerivative,
+	KindEscapeSequence,
+	KindExpression,
+	KindFactorial,
+	KindFunction,
+	KindFunctionCall,
+	KindImplicitMultiply,
+	KindInfixApplication,
+	KindInformation,
+	KindList,
+	KindMapApply,
+	KindMessageName,
+	KindNamedCharacter,
+	KindNot,
+	KindNumber,
+	KindOptionalPattern,
+	KindOr,
+	KindOutReference,
+	KindParenthesizedExpression,
+	KindPart,
+	KindPattern,
+	KindPatternBind,
+	KindPatternDefault,
+	KindPatternTest,
+	KindPostfixApplication,
+	KindPrefixApplication,
+	KindRepeated,
+	KindRepeatedNull,
+	KindReplaceAll,
+	KindReplaceRepeated,
+	KindRule,
+	KindRuleDelayed,
+	KindSet,
+	KindSetDelayed,
+	KindSlot,
+	KindSourceFile,
+	KindSpan,
+	KindString,
+	KindStringExpression,
+	KindStringJoin,
+	KindSymbol,
+	KindUnaryExpression,
+	KindVarRestPattern,
+}
+
+// Kinds returns every named node kind in node-types.json, sorted by name.
+func Kinds() []Kind {
+	return append([]Kind(nil), kinds...)
+}
+
+// KindOf returns the kind of node, or "" for a nil node. Anonymous nodes
+// such as operators and brackets have no constant; their Kind is still the
+// token text, for example "+".
+func KindOf(node *tree_sitter.Node) Kind {
+	return Kind(NodeKind(node))
+}
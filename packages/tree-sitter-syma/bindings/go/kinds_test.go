@@ -0,0 +1,59 @@
+package tree_sitter_syma_test
+
+import (
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestKinds(t *testing.T) {
+	language := tree_sitter.NewLanguage(tree_sitter_syma.Language())
+
+	known := map[tree_sitter_syma.Kind]bool{}
+	for _, kind := range tree_sitter_syma.Kinds() {
+		known[kind] = true
+		id := language.IdForNodeKind(string(kind), true)
+		if id == 0 {
+			t.Errorf("%q is not a node kind of the grammar", kind)
+			continue
+		}
+		if !language.NodeKindIsVisible(id) {
+			t.Errorf("%q is a hidden node kind", kind)
+		}
+	}
+
+	// Every visible named kind the language reports has a constant.
+	for id := uint16(1); uint32(id) < language.NodeKindCount(); id++ {
+		if !language.NodeKindIsNamed(id) || !language.NodeKindIsVisible(id) {
+			continue
+		}
+		if kind := language.NodeKindForId(id); !known[tree_sitter_syma.Kind(kind)] {
+			t.Errorf("node kind %q has no constant", kind)
+		}
+	}
+
+	if id := language.IdForNodeKind(string(tree_sitter_syma.KindError), true); id == 0 {
+		t.Errorf("%q is not a node kind of the grammar", tree_sitter_syma.KindError)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("f[x] -> 1")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	rule := tree.RootNode().Child(0).Child(0)
+	if kind := tree_sitter_syma.KindOf(rule); kind != tree_sitter_syma.KindRule {
+		t.Fatalf("KindOf = %q, want %q", kind, tree_sitter_syma.KindRule)
+	}
+	head := rule.ChildByFieldName("left").Child(0).ChildByFieldName("head").Child(0)
+	if kind := tree_sitter_syma.KindOf(head); kind != tree_sitter_syma.KindSymbol {
+		t.Errorf("KindOf(head) = %q, want %q", kind, tree_sitter_syma.KindSymbol)
+	}
+	if kind := tree_sitter_syma.KindOf(nil); kind != "" {
+		t.Errorf("KindOf(nil) = %q, want \"\"", kind)
+	}
+}
@@ -0,0 +1,83 @@
+package tree_sitter_syma_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+// nested returns depth applications of f around x: f[f[...f[x]...]].
+func nested(depth int) []byte {
+	return []byte(strings.Repeat("f[", depth) + "x" + strings.Repeat("]", depth))
+}
+
+// chain returns a sum of width terms: a + a + ... + a.
+func chain(width int) []byte {
+	return []byte(strings.Repeat("a + ", width-1) + "a")
+}
+
+// grid returns width lists side by side, each nested depth deep:
+// {{{x}}, {{x}}, ...} with commas so that every level is a list.
+func grid(depth, width int) []byte {
+	inner := strings.Repeat("{", depth) + "x" + strings.Repeat(",}", depth)
+	return []byte("{" + strings.Repeat(inner+", ", width-1) + inner + "}")
+}
+
+func TestParseDeepNesting(t *testing.T) {
+	for _, source := range [][]byte{nested(10000), chain(10000), grid(1000, 10)} {
+		tree, err := tree_sitter_syma.Parse(source)
+		if err != nil {
+			t.Fatalf("Parse returned an error: %v", err)
+		}
+		if tree_sitter_syma.HasError(tree) {
+			t.Errorf("%.20s...: %v", source, tree_sitter_syma.FirstError(tree, source))
+		}
+		nodes := 0
+		tree_sitter_syma.Walk(tree, func(*tree_sitter.Node) bool {
+			nodes++
+			return true
+		})
+		if nodes < 10000 {
+			t.Errorf("%.20s...: walked %d nodes, want at least 10000", source, nodes)
+		}
+		tree.Close()
+	}
+}
+
+func benchmarkParse(b *testing.B, source []byte) {
+	b.SetBytes(int64(len(source)))
+	for i := 0; i < b.N; i++ {
+		tree, err := tree_sitter_syma.Parse(source)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tree.Close()
+	}
+}
+
+func BenchmarkParseNested(b *testing.B) {
+	for _, depth := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			benchmarkParse(b, nested(depth))
+		})
+	}
+}
+
+func BenchmarkParseChain(b *testing.B) {
+	for _, width := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("width=%d", width), func(b *testing.B) {
+			benchmarkParse(b, chain(width))
+		})
+	}
+}
+
+func BenchmarkParseGrid(b *testing.B) {
+	for _, size := range [][2]int{{10, 100}, {100, 10}, {1000, 1}} {
+		b.Run(fmt.Sprintf("depth=%d/width=%d", size[0], size[1]), func(b *testing.B) {
+			benchmarkParse(b, grid(size[0], size[1]))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package tree_sitter_syma
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// NodeText returns the text of node in source, the buffer the tree was
+// parsed from.
+//
+// Offsets past the end of source are clamped, so a node from a tree that
+// does not belong to source yields a partial or empty string rather than a
+// panic. A nil node has no text.
+func NodeText(node *tree_sitter.Node, source []byte) string {
+	if node == nil {
+		return ""
+	}
+	start, end := node.StartByte(), node.EndByte()
+	if end > uint(len(source)) {
+		end = uint(len(source))
+	}
+	if start > end {
+		return ""
+	}
+	return string(source[start:end])
+}
+
+// NodeKind returns the grammar type of node, such as "symbol" or
+// "application", or "" for a nil node.
+func NodeKind(node *tree_sitter.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Kind()
+}
+
+// FindNodeAt returns the deepest named node that contains point, for hit
+// testing an editor cursor. A cursor inside foo in foo + 1 finds the
+// symbol, not the expression around it. Between tokens the result is the
+// smallest named node that encloses the gap.
+//
+// FindNodeAt returns nil when point lies past the end of the source, which
+// is where the root node ends. The root starts after any leading blank
+// lines, but a cursor on them is still in the document and finds the root.
+// The end counts as inside, since a cursor can sit after the last byte.
+func FindNodeAt(tree *tree_sitter.Tree, point tree_sitter.Point) *tree_sitter.Node {
+	if tree == nil {
+		return nil
+	}
+	root := tree.RootNode()
+	if pointBefore(root.EndPosition(), point) {
+		return nil
+	}
+	return root.NamedDescendantForPointRange(point, point)
+}
+
+func pointBefore(a, b tree_sitter.Point) bool {
+	return a.Row < b.Row || (a.Row == b.Row && a.Column < b.Column)
+}
@@ -0,0 +1,113 @@
+package tree_sitter_syma_test
+
+import (
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestNodeText(t *testing.T) {
+	source := []byte("x + 1")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	sum := tree.RootNode().Child(0).Child(0)
+	if kind := tree_sitter_syma.NodeKind(sum); kind != "binary_expression" {
+		t.Fatalf("NodeKind = %q, want %q", kind, "binary_expression")
+	}
+	operator := sum.ChildByFieldName("operator")
+	if text := tree_sitter_syma.NodeText(operator, source); text != "+" {
+		t.Errorf("operator text = %q, want %q", text, "+")
+	}
+	if text := tree_sitter_syma.NodeText(sum, source); text != "x + 1" {
+		t.Errorf("expression text = %q, want %q", text, "x + 1")
+	}
+}
+
+func TestNodeTextOutOfRange(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("longSymbol + 1")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if text := tree_sitter_syma.NodeText(root, []byte("long")); text != "long" {
+		t.Errorf("text with a short source = %q, want %q", text, "long")
+	}
+	if text := tree_sitter_syma.NodeText(root, nil); text != "" {
+		t.Errorf("text with no source = %q, want empty", text)
+	}
+}
+
+func TestNodeNil(t *testing.T) {
+	if text := tree_sitter_syma.NodeText(nil, []byte("x")); text != "" {
+		t.Errorf("NodeText(nil) = %q, want empty", text)
+	}
+	if kind := tree_sitter_syma.NodeKind(nil); kind != "" {
+		t.Errorf("NodeKind(nil) = %q, want empty", kind)
+	}
+}
+
+func TestFindNodeAt(t *testing.T) {
+	source := []byte("x = foo + 1\nbar[2]")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	tests := []struct {
+		point tree_sitter.Point
+		kind  string
+		text  string
+	}{
+		// Inside foo: the symbol, not the expression that wraps it.
+		{tree_sitter.Point{Row: 0, Column: 5}, "symbol", "foo"},
+		// On the anonymous +: the named node around it.
+		{tree_sitter.Point{Row: 0, Column: 8}, "binary_expression", "foo + 1"},
+		{tree_sitter.Point{Row: 1, Column: 4}, "number", "2"},
+		{tree_sitter.Point{Row: 1, Column: 0}, "symbol", "bar"},
+	}
+	for _, test := range tests {
+		node := tree_sitter_syma.FindNodeAt(tree, test.point)
+		if node == nil {
+			t.Errorf("FindNodeAt(%v) = nil, want %s", test.point, test.kind)
+			continue
+		}
+		if kind, text := node.Kind(), tree_sitter_syma.NodeText(node, source); kind != test.kind || text != test.text {
+			t.Errorf("FindNodeAt(%v) = %s %q, want %s %q", test.point, kind, text, test.kind, test.text)
+		}
+	}
+
+	if node := tree_sitter_syma.FindNodeAt(tree, tree_sitter.Point{Row: 5, Column: 0}); node != nil {
+		t.Errorf("FindNodeAt past the end = %s, want nil", node.Kind())
+	}
+	if node := tree_sitter_syma.FindNodeAt(nil, tree_sitter.Point{}); node != nil {
+		t.Errorf("FindNodeAt(nil) = %s, want nil", node.Kind())
+	}
+}
+
+func TestFindNodeAtLeadingBlankLines(t *testing.T) {
+	source := []byte("\n\n  foo + 1")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	// The root starts at foo, but the lines above it are in the document.
+	for _, point := range []tree_sitter.Point{{Row: 0, Column: 0}, {Row: 1, Column: 0}, {Row: 2, Column: 1}} {
+		node := tree_sitter_syma.FindNodeAt(tree, point)
+		if node == nil || node.Kind() != "source_file" {
+			t.Errorf("FindNodeAt(%v) = %v, want the source_file", point, node)
+		}
+	}
+	if node := tree_sitter_syma.FindNodeAt(tree, tree_sitter.Point{Row: 2, Column: 3}); node == nil || node.Kind() != "symbol" {
+		t.Errorf("FindNodeAt on foo = %v, want the symbol", node)
+	}
+}
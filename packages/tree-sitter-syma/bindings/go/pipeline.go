@@ -0,0 +1,47 @@
+package tree_sitter_syma
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// FlattenPipeline returns the stages of a // pipeline in the order they
+// run: for data // f // g // h it returns the expressions data, f, g and h.
+//
+// node may be the postfix_application at the top of the chain or the
+// expression that wraps it. The grammar nests pipelines to the left, so
+// the chain is followed down the argument side; a parenthesized part,
+// (data // f) // g, is a single stage. A node that is not a pipeline is
+// returned as the only stage, and a nil node gives nil.
+func FlattenPipeline(node *tree_sitter.Node) []*tree_sitter.Node {
+	if node == nil {
+		return nil
+	}
+	var stages []*tree_sitter.Node
+	for {
+		pipe := pipelineAt(node)
+		if pipe == nil {
+			break
+		}
+		stages = append(stages, pipe.ChildByFieldName("function"))
+		node = pipe.ChildByFieldName("argument")
+	}
+	stages = append(stages, node)
+
+	// The stages were collected last first.
+	for i, j := 0, len(stages)-1; i < j; i, j = i+1, j-1 {
+		stages[i], stages[j] = stages[j], stages[i]
+	}
+	return stages
+}
+
+// pipelineAt returns node if it is a postfix_application, or the one it
+// wraps if node is an expression around one, and nil otherwise.
+func pipelineAt(node *tree_sitter.Node) *tree_sitter.Node {
+	kind := KindOf(node)
+	if kind == KindExpression && node.NamedChildCount() == 1 {
+		node = node.NamedChild(0)
+		kind = KindOf(node)
+	}
+	if kind != KindPostfixApplication {
+		return nil
+	}
+	return node
+}
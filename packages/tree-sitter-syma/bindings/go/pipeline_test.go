@@ -0,0 +1,68 @@
+package tree_sitter_syma_test
+
+import (
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestFlattenPipeline(t *testing.T) {
+	source := []byte("data // f // g[1] // h")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	want := []string{"data", "f", "g[1]", "h"}
+	expression := tree.RootNode().Child(0)
+	// Both the expression and the postfix_application inside it work.
+	for _, node := range []*tree_sitter.Node{expression, expression.Child(0)} {
+		stages := tree_sitter_syma.FlattenPipeline(node)
+		if len(stages) != len(want) {
+			t.Fatalf("%s: %d stages, want %d", node.Kind(), len(stages), len(want))
+		}
+		for i, stage := range stages {
+			if text := tree_sitter_syma.NodeText(stage, source); text != want[i] {
+				t.Errorf("%s: stage %d = %q, want %q", node.Kind(), i, text, want[i])
+			}
+		}
+	}
+}
+
+func TestFlattenPipelineParentheses(t *testing.T) {
+	source := []byte("(data // f) // g")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	stages := tree_sitter_syma.FlattenPipeline(tree.RootNode().Child(0))
+	want := []string{"(data // f)", "g"}
+	if len(stages) != len(want) {
+		t.Fatalf("%d stages, want %d", len(stages), len(want))
+	}
+	for i, stage := range stages {
+		if text := tree_sitter_syma.NodeText(stage, source); text != want[i] {
+			t.Errorf("stage %d = %q, want %q", i, text, want[i])
+		}
+	}
+}
+
+func TestFlattenPipelineNotAPipeline(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("f[x]")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	top := tree.RootNode().Child(0)
+	if stages := tree_sitter_syma.FlattenPipeline(top); len(stages) != 1 || stages[0].Id() != top.Id() {
+		t.Errorf("FlattenPipeline(f[x]) = %v, want just the node", stages)
+	}
+	if stages := tree_sitter_syma.FlattenPipeline(nil); stages != nil {
+		t.Errorf("FlattenPipeline(nil) = %v, want nil", stages)
+	}
+}
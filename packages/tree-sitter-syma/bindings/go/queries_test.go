@@ -0,0 +1,229 @@
+package tree_sitter_syma_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+// runQueryFile runs the query in queries/name over the fixture at path.
+func runQueryFile(t *testing.T, name, path string) []tree_sitter_syma.QueryCapture {
+	t.Helper()
+	query, err := os.ReadFile("../../queries/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	t.Cleanup(tree.Close)
+	if tree_sitter_syma.HasError(tree) {
+		t.Fatalf("%s: %v", path, tree_sitter_syma.FirstError(tree, source))
+	}
+	captures, err := tree_sitter_syma.Query(tree, source, string(query))
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return captures
+}
+
+func TestFolds(t *testing.T) {
+	captures := runQueryFile(t, "folds.scm", "../../test/folds/nested.syma")
+
+	// Fold ranges as zero-based start and end rows, for the captures that
+	// span more than one line.
+	type fold struct{ start, end uint }
+	var folds []fold
+	for i, capture := range captures {
+		if capture.Name != "fold" {
+			t.Errorf("capture %d is @%s, want @fold", i, capture.Name)
+		}
+		start, end := capture.Node.StartPosition().Row, capture.Node.EndPosition().Row
+		if start < end {
+			folds = append(folds, fold{start, end})
+		}
+	}
+	want := []fold{{0, 6}, {1, 4}, {8, 11}, {13, 15}, {19, 22}}
+	if len(folds) != len(want) {
+		t.Fatalf("folds = %v, want %v", folds, want)
+	}
+	for i := range want {
+		if folds[i] != want[i] {
+			t.Errorf("fold %d = %v, want %v", i, folds[i], want[i])
+		}
+	}
+
+	// Folds of different nodes nest or are disjoint; none share a range.
+	for i, a := range captures {
+		for _, b := range captures[i+1:] {
+			as, ae := a.Node.StartByte(), a.Node.EndByte()
+			bs, be := b.Node.StartByte(), b.Node.EndByte()
+			if as == bs && ae == be {
+				t.Errorf("%q and %q fold the same range", a.Text, b.Text)
+			}
+			nested := (as <= bs && be <= ae) || (bs <= as && ae <= be)
+			if !nested && as < be && bs < ae {
+				t.Errorf("folds %q and %q overlap", a.Text, b.Text)
+			}
+		}
+	}
+}
+
+func TestIndents(t *testing.T) {
+	const path = "../../test/indents/brackets.syma"
+	captures := runQueryFile(t, "indents.scm", path)
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As in editors that use these captures: each line is indented once for
+	// every row that opens an @indent.begin node still open at that line,
+	// and a line that starts with an @indent.branch token goes back one level.
+	type span struct{ start, end uint }
+	var begins []span
+	branches := map[uint]bool{}
+	for _, capture := range captures {
+		start, end := capture.Node.StartPosition(), capture.Node.EndPosition()
+		switch capture.Name {
+		case "indent.begin":
+			begins = append(begins, span{start.Row, end.Row})
+		case "indent.branch":
+			branches[start.Row] = branches[start.Row] || isLineStart(source, capture.Node.StartByte())
+		case "indent.end":
+		default:
+			t.Errorf("unexpected capture @%s", capture.Name)
+		}
+	}
+
+	for row, line := range strings.Split(string(source), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		opened := map[uint]bool{}
+		for _, b := range begins {
+			if b.start < uint(row) && uint(row) <= b.end {
+				opened[b.start] = true
+			}
+		}
+		level := len(opened)
+		if branches[uint(row)] {
+			level--
+		}
+		want := (len(line) - len(strings.TrimLeft(line, " "))) / 2
+		if level != want {
+			t.Errorf("line %d %q: indent level %d, want %d", row+1, line, level, want)
+		}
+	}
+}
+
+// isLineStart reports whether only spaces precede offset on its line.
+func isLineStart(source []byte, offset uint) bool {
+	for i := int(offset) - 1; i >= 0 && source[i] != '\n'; i-- {
+		if source[i] != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// TestInjections checks which nodes get an injected language and the range
+// handed to it. The language comes from each pattern's #set! property, which
+// Query does not report, so the query is run directly.
+func TestInjections(t *testing.T) {
+	const path = "../../test/injections/strings.syma"
+	pattern, err := os.ReadFile("../../queries/injections.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+	if tree_sitter_syma.HasError(tree) {
+		t.Fatalf("%s: %v", path, tree_sitter_syma.FirstError(tree, source))
+	}
+
+	query, queryErr := tree_sitter.NewQuery(tree_sitter.NewLanguage(tree_sitter_syma.Language()), string(pattern))
+	if queryErr != nil {
+		t.Fatalf("injections.scm: %v", queryErr)
+	}
+	defer query.Close()
+	content, ok := query.CaptureIndexForName("injection.content")
+	if !ok {
+		t.Fatal("injections.scm has no @injection.content capture")
+	}
+
+	type injection struct {
+		language string
+		text     string
+		row      uint
+		column   uint
+	}
+	var got []injection
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+	matches := cursor.Matches(query, tree.RootNode(), source)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		language := ""
+		for _, property := range query.PropertySettings(match.PatternIndex) {
+			if property.Key == "injection.language" && property.Value != nil {
+				language = *property.Value
+			}
+		}
+		// #offset! moves the start and end of the range by rows and
+		// columns. The strings here are on one line, so the rows stay put
+		// and a column moves the byte offset by as much.
+		var startOffset, endOffset int
+		for _, predicate := range query.GeneralPredicates(match.PatternIndex) {
+			if predicate.Operator != "offset!" || len(predicate.Args) != 5 {
+				continue
+			}
+			startOffset, err = strconv.Atoi(*predicate.Args[2].String)
+			if err != nil {
+				t.Fatalf("#offset!: %v", err)
+			}
+			endOffset, err = strconv.Atoi(*predicate.Args[4].String)
+			if err != nil {
+				t.Fatalf("#offset!: %v", err)
+			}
+		}
+		for _, capture := range match.Captures {
+			if uint(capture.Index) != content {
+				continue
+			}
+			start := capture.Node.StartPosition()
+			startByte := int(capture.Node.StartByte()) + startOffset
+			endByte := int(capture.Node.EndByte()) + endOffset
+			got = append(got, injection{language, string(source[startByte:endByte]), start.Row, start.Column + uint(startOffset)})
+		}
+	}
+
+	want := []injection{
+		{"comment", "(* TODO: comments are injected as well *)", 0, 0},
+		{"regex", `[a-z]+\\d*`, 1, 35},
+		{"markdown", `f[x] gives *x* squared.`, 3, 12},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("injections = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("injection %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tree_sitter_syma
+
+import (
+	"errors"
+	"fmt"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// QueryCapture is one node captured by a query.
+type QueryCapture struct {
+	// Name is the capture name without the leading "@".
+	Name string
+	// Node is the captured node. It is only valid while its tree is open.
+	Node tree_sitter.Node
+	// Text is the source text of Node.
+	Text string
+}
+
+// Query runs the tree-sitter query pattern over tree and returns every
+// capture of every match, in match order. source must be the buffer the tree
+// was parsed from.
+//
+// A pattern that does not compile against Language is reported as an error
+// carrying the position of the problem.
+func Query(tree *tree_sitter.Tree, source []byte, pattern string) ([]QueryCapture, error) {
+	if tree == nil {
+		return nil, errors.New("tree_sitter_syma: query on a nil tree")
+	}
+	language := tree_sitter.NewLanguage(Language())
+	if language == nil {
+		return nil, errors.New("tree_sitter_syma: could not load the Syma language")
+	}
+	query, queryErr := tree_sitter.NewQuery(language, pattern)
+	if queryErr != nil {
+		return nil, fmt.Errorf("tree_sitter_syma: %w", queryErr)
+	}
+	defer query.Close()
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	names := query.CaptureNames()
+	var captures []QueryCapture
+	matches := cursor.Matches(query, tree.RootNode(), source)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		for _, capture := range match.Captures {
+			captures = append(captures, QueryCapture{
+				Name: names[capture.Index],
+				Node: capture.Node,
+				Text: NodeText(&capture.Node, source),
+			})
+		}
+	}
+	return captures, nil
+}
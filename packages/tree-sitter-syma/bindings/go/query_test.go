@@ -0,0 +1,71 @@
+package tree_sitter_syma_test
+
+import (
+	"strings"
+	"testing"
+
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestQuery(t *testing.T) {
+	source := []byte("a + b")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	captures, err := tree_sitter_syma.Query(tree, source, "(symbol) @s")
+	if err != nil {
+		t.Fatalf("Query returned an error: %v", err)
+	}
+	if len(captures) != 2 {
+		t.Fatalf("got %d captures, want 2", len(captures))
+	}
+	for i, want := range []string{"a", "b"} {
+		capture := captures[i]
+		if capture.Name != "s" {
+			t.Errorf("capture %d name = %q, want %q", i, capture.Name, "s")
+		}
+		if capture.Text != want {
+			t.Errorf("capture %d text = %q, want %q", i, capture.Text, want)
+		}
+		if kind := capture.Node.Kind(); kind != "symbol" {
+			t.Errorf("capture %d kind = %q, want %q", i, kind, "symbol")
+		}
+	}
+}
+
+func TestQueryFields(t *testing.T) {
+	source := []byte("f[x_] := x^2")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	captures, err := tree_sitter_syma.Query(tree, source,
+		"(set_delayed left: (expression (application head: (expression (symbol) @name))))")
+	if err != nil {
+		t.Fatalf("Query returned an error: %v", err)
+	}
+	if len(captures) != 1 || captures[0].Name != "name" || captures[0].Text != "f" {
+		t.Fatalf("captures = %+v, want one @name capture of %q", captures, "f")
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("a")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	_, err = tree_sitter_syma.Query(tree, []byte("a"), "(no_such_node) @n")
+	if err == nil {
+		t.Fatal("Query with an unknown node type succeeded")
+	}
+	if !strings.Contains(err.Error(), "no_such_node") {
+		t.Errorf("error %q does not name the bad node type", err)
+	}
+}
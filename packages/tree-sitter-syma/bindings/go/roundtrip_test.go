@@ -0,0 +1,155 @@
+package tree_sitter_syma_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+// A corpus test is a header between two lines of =, then its input, then a
+// line of - and the expected tree.
+var (
+	corpusHeader  = regexp.MustCompile(`(?m)^={3,}\n(.*)\n(?:.*\n)*?={3,}\n`)
+	corpusDivider = regexp.MustCompile(`(?m)^-{3,}\n`)
+)
+
+// corpusInputs returns the input of every test in the corpus file at path,
+// keyed by test name.
+func corpusInputs(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	inputs := map[string]string{}
+	headers := corpusHeader.FindAllStringSubmatchIndex(text, -1)
+	for i, header := range headers {
+		end := len(text)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+		body := text[header[1]:end]
+		divider := corpusDivider.FindStringIndex(body)
+		if divider == nil {
+			t.Fatalf("%s: test %q has no --- line", path, text[header[2]:header[3]])
+		}
+		inputs[text[header[2]:header[3]]] = strings.Trim(body[:divider[0]], "\n")
+	}
+	return inputs
+}
+
+// reconstruct rebuilds source from the leaves of tree, for checking that
+// the grammar accounts for every character it was given. It concatenates
+// the text of each leaf token, comments included, in order, and keeps the
+// gaps between them only where they hold nothing but whitespace and line
+// continuations, which the grammar skips as extras. Any other gap is text
+// the lexer consumed without producing a token, and is dropped, so the
+// result equals source exactly when no character was lost.
+//
+// A string is copied whole, since its plain content is a hidden token that
+// does not appear in the tree, and so is an ERROR node, whose text the
+// parser could not place. Characters lost inside either are not detected.
+func reconstruct(tree *tree_sitter.Tree, source []byte) []byte {
+	out := make([]byte, 0, len(source))
+	var end uint
+	tree_sitter_syma.Walk(tree, func(node *tree_sitter.Node) bool {
+		if node.ChildCount() > 0 && !node.IsError() && tree_sitter_syma.KindOf(node) != tree_sitter_syma.KindString {
+			return true
+		}
+		start := node.StartByte()
+		out = appendGap(out, source[end:start])
+		out = append(out, source[start:node.EndByte()]...)
+		end = node.EndByte()
+		return false
+	})
+	return appendGap(out, source[end:])
+}
+
+// appendGap appends gap to out if the grammar would skip all of it.
+func appendGap(out, gap []byte) []byte {
+	for i := 0; i < len(gap); i++ {
+		switch gap[i] {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+		case '\\':
+			// A line continuation, \ then a newline.
+			if i+1 < len(gap) && gap[i+1] == '\n' {
+				i++
+			} else if i+2 < len(gap) && gap[i+1] == '\r' && gap[i+2] == '\n' {
+				i += 2
+			} else {
+				return out
+			}
+		default:
+			return out
+		}
+	}
+	return append(out, gap...)
+}
+
+// checkRoundTrip parses source and fails if its leaves do not rebuild it.
+func checkRoundTrip(t *testing.T, source []byte) {
+	t.Helper()
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+	if got := reconstruct(tree, source); string(got) != string(source) {
+		t.Errorf("reconstruct = %q, want %q", got, source)
+	}
+}
+
+func TestRoundTripCorpus(t *testing.T) {
+	paths, err := filepath.Glob("../../test/corpus/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no corpus files found")
+	}
+	for _, path := range paths {
+		for name, input := range corpusInputs(t, path) {
+			t.Run(filepath.Base(path)+"/"+name, func(t *testing.T) {
+				checkRoundTrip(t, []byte(input))
+			})
+		}
+	}
+}
+
+func TestRoundTripComments(t *testing.T) {
+	const path = "../../test/roundtrip/comments.syma"
+	source, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+	if tree_sitter_syma.HasError(tree) {
+		t.Fatalf("%s: %v", path, tree_sitter_syma.FirstError(tree, source))
+	}
+	checkRoundTrip(t, source)
+}
+
+func TestReconstructDropsSkippedText(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("a + b")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	// The same token ranges over a source whose gaps are not whitespace, as
+	// if the lexer had skipped the # characters.
+	source := []byte("a#+#b")
+	if got := string(reconstruct(tree, source)); got != "a+b" {
+		t.Errorf("reconstruct = %q, want %q", got, "a+b")
+	}
+}
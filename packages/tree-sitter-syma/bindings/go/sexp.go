@@ -0,0 +1,86 @@
+package tree_sitter_syma
+
+import (
+	"fmt"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Sexp renders tree as a one-line s-expression of its named nodes, with
+// field names, in the form the corpus tests use:
+//
+//	(source_file (expression (binary_expression left: (expression (symbol)) right: (expression (symbol)))))
+//
+// A nil tree renders as "".
+func Sexp(tree *tree_sitter.Tree) string {
+	if tree == nil {
+		return ""
+	}
+	return tree.RootNode().ToSexp()
+}
+
+// PrettyTree renders tree with one named node per line, indented by depth,
+// with field names and row/column ranges as in `tree-sitter parse`:
+//
+//	(source_file [0, 0] - [0, 5]
+//	  (expression [0, 0] - [0, 5]
+//	    (binary_expression [0, 0] - [0, 5]
+//	      left: (expression [0, 0] - [0, 1]
+//	        (symbol [0, 0] - [0, 1] "a"))
+//	      right: (expression [0, 4] - [0, 5]
+//	        (symbol [0, 4] - [0, 5] "b")))))
+//
+// When source is the buffer the tree was parsed from, nodes without named
+// children also show their text; pass nil to leave it out. Missing tokens
+// that error recovery inserted are shown as (MISSING "]").
+func PrettyTree(tree *tree_sitter.Tree, source []byte) string {
+	if tree == nil {
+		return ""
+	}
+	var b strings.Builder
+	cursor := tree.Walk()
+	defer cursor.Close()
+
+	// Only tokens are anonymous, and tokens have no children, so the
+	// cursor depth of a node is also its depth among the nodes written.
+	open := 0 // parentheses left open by the nodes written so far
+	for {
+		node := cursor.Node()
+		descend := false
+		if node.IsNamed() || node.IsMissing() {
+			depth := int(cursor.Depth())
+			if open > 0 {
+				b.WriteString(strings.Repeat(")", open-depth))
+				b.WriteByte('\n')
+			}
+			open = depth + 1
+
+			b.WriteString(strings.Repeat("  ", depth))
+			if field := cursor.FieldName(); field != "" {
+				b.WriteString(field)
+				b.WriteString(": ")
+			}
+			start, end := node.StartPosition(), node.EndPosition()
+			if node.IsMissing() {
+				fmt.Fprintf(&b, "(MISSING %q", node.Kind())
+			} else {
+				b.WriteString("(" + node.Kind())
+				descend = true
+			}
+			fmt.Fprintf(&b, " [%d, %d] - [%d, %d]", start.Row, start.Column, end.Row, end.Column)
+			if source != nil && !node.IsMissing() && node.NamedChildCount() == 0 {
+				fmt.Fprintf(&b, " %q", NodeText(node, source))
+			}
+		}
+		if descend && cursor.GotoFirstChild() {
+			continue
+		}
+		for !cursor.GotoNextSibling() {
+			if !cursor.GotoParent() {
+				b.WriteString(strings.Repeat(")", open))
+				return b.String()
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tree_sitter_syma_test
+
+import (
+	"strings"
+	"testing"
+
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestSexp(t *testing.T) {
+	tree, err := tree_sitter_syma.ParseString("a + b")
+	if err != nil {
+		t.Fatalf("ParseString returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	got := tree_sitter_syma.Sexp(tree)
+	want := "(binary_expression left: (expression (symbol)) right: (expression (symbol)))"
+	if !strings.Contains(got, want) {
+		t.Errorf("Sexp = %s, want it to contain %s", got, want)
+	}
+	if got := tree_sitter_syma.Sexp(nil); got != "" {
+		t.Errorf("Sexp(nil) = %q, want \"\"", got)
+	}
+}
+
+func TestPrettyTree(t *testing.T) {
+	source := []byte("a + b")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	want := `(source_file [0, 0] - [0, 5]
+  (expression [0, 0] - [0, 5]
+    (binary_expression [0, 0] - [0, 5]
+      left: (expression [0, 0] - [0, 1]
+        (symbol [0, 0] - [0, 1] "a"))
+      right: (expression [0, 4] - [0, 5]
+        (symbol [0, 4] - [0, 5] "b")))))`
+	if got := tree_sitter_syma.PrettyTree(tree, source); got != want {
+		t.Errorf("PrettyTree =\n%s\nwant\n%s", got, want)
+	}
+
+	// Without a source there are no snippets.
+	if got := tree_sitter_syma.PrettyTree(tree, nil); strings.Contains(got, `"a"`) {
+		t.Errorf("PrettyTree without source shows text:\n%s", got)
+	}
+}
+
+func TestPrettyTreeMissing(t *testing.T) {
+	source := []byte("f[x")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	want := `(source_file [0, 0] - [0, 3]
+  (expression [0, 0] - [0, 3]
+    (application [0, 0] - [0, 3]
+      head: (expression [0, 0] - [0, 1]
+        (symbol [0, 0] - [0, 1] "f"))
+      arguments: (expression [0, 2] - [0, 3]
+        (symbol [0, 2] - [0, 3] "x"))
+      (MISSING "]" [0, 3] - [0, 3]))))`
+	if got := tree_sitter_syma.PrettyTree(tree, source); got != want {
+		t.Errorf("PrettyTree =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+package tree_sitter_syma
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// Walk visits every node of tree in pre-order, depth first, calling fn for
+// each one. When fn returns false the children of that node are skipped.
+//
+// Walk moves a single TreeCursor over the tree instead of recursing, so deep
+// trees cost no extra stack.
+func Walk(tree *tree_sitter.Tree, fn func(node *tree_sitter.Node) bool) {
+	cursor := tree.Walk()
+	defer cursor.Close()
+
+	for {
+		if fn(cursor.Node()) && cursor.GotoFirstChild() {
+			continue
+		}
+		for !cursor.GotoNextSibling() {
+			if !cursor.GotoParent() {
+				return
+			}
+		}
+	}
+}
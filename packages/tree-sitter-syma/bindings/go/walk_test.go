@@ -0,0 +1,52 @@
+package tree_sitter_syma_test
+
+import (
+	"reflect"
+	"testing"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_syma "github.com/tree-sitter/tree-sitter-syma/bindings/go"
+)
+
+func TestWalk(t *testing.T) {
+	source := []byte("f[g[x], y] -> h")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	var symbols []string
+	tree_sitter_syma.Walk(tree, func(node *tree_sitter.Node) bool {
+		if node.Kind() == "symbol" {
+			symbols = append(symbols, node.Utf8Text(source))
+		}
+		return true
+	})
+	want := []string{"f", "g", "x", "y", "h"}
+	if !reflect.DeepEqual(symbols, want) {
+		t.Errorf("symbols = %q, want %q", symbols, want)
+	}
+}
+
+func TestWalkPrunes(t *testing.T) {
+	source := []byte("f[g[x], y]")
+	tree, err := tree_sitter_syma.Parse(source)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	defer tree.Close()
+
+	var symbols []string
+	tree_sitter_syma.Walk(tree, func(node *tree_sitter.Node) bool {
+		if node.Kind() == "symbol" {
+			symbols = append(symbols, node.Utf8Text(source))
+		}
+		// Skip the arguments of the inner application.
+		return node.Kind() != "application" || node.Utf8Text(source) != "g[x]"
+	})
+	want := []string{"f", "y"}
+	if !reflect.DeepEqual(symbols, want) {
+		t.Errorf("symbols = %q, want %q", symbols, want)
+	}
+}
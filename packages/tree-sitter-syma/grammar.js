@@ -1,98 +1,743 @@
+// Operator precedences follow Mathematica's operator table: a higher number
+// binds tighter.
+const PREC = {
+  information: -1,
+  compound: 10,
+  assign: 40,
+  postfix: 70,
+  function: 90,
+  compound_assign: 100,
+  replace: 110,
+  rule: 120,
+  condition: 130,
+  string_expression: 135,
+  pattern_bind: 150,
+  alternatives: 160,
+  repeated: 170,
+  or: 205,
+  and: 215,
+  comparison: 290,
+  infix: 300,
+  span: 305,
+  plus: 310,
+  times: 400,
+  unary: 480,
+  not: 485,
+  power: 590,
+  string_join: 600,
+  apply: 620,
+  composition: 625,
+  prefix: 640,
+  factorial: 660,
+  derivative: 670,
+  pattern_test: 680,
+  call: 1000,
+};
+
+// The precision mark of a number: 1.5`, 1.5`20 or 1.5``20.
+const PRECISION = /``?(\d+(\.\d+)?)?/;
+
+// A named character such as \[Alpha], which stands for one letter.
+const NAMED_CHARACTER = /\\\[[A-Za-z][A-Za-z0-9]*\]/;
+
+// A symbol name without contexts. A named character counts as a letter.
+const NAME = new RegExp(
+  '([a-zA-Z$\\u00A0-\\uFFFF]|' + NAMED_CHARACTER.source + ')' +
+  '([a-zA-Z0-9$\\u00A0-\\uFFFF]|' + NAMED_CHARACTER.source + ')*'
+);
+
+// A later part of a compound Syma name: Core/Main, Rope/catN/0,
+// with-one-of, Eff->Pending, Eff:SimplePrint. After a / it may be all
+// digits.
+const NAME_PART = choice(
+  seq('/', /[a-zA-Z0-9$\u00A0-\uFFFF]+/),
+  seq(choice('-', '->', ':'), NAME)
+);
+
 module.exports = grammar({
   name: 'syma',
 
-  extras: $ => [
-    /\s/,
-    $.comment
+  // Comments and string contents are lexed in src/scanner.c: comments so
+  // that (* ... *) can nest and so that ; and // comments are told apart
+  // from the operators they share, string contents so that a `(*` inside a
+  // string is not taken for a comment. _error_sentinel is never valid in a
+  // real parse state; the scanner uses it to spot error recovery.
+  //
+  // Brackets are lexed there too. The scanner keeps a stack of the open
+  // brackets, so that `]]` is one token only when it closes a `[[`: in
+  // a[[f[x]]] the first `]` closes f[ and the last two close the part.
+  //
+  // _argument_minus is the `-` of a negated brace-call argument (see
+  // brace_call), which only the scanner can tell by the space before it,
+  // _number_trailing_dot a real such as 5. (see number),
+  // _operator_symbol an operator used as a brace-call head (see
+  // brace_call) and _juxtaposition the empty gap between the factors of an
+  // implicit product (see implicit_multiply). Like _error_sentinel,
+  // _optional_operand and _immediate_operand are never lexed. They mark
+  // where an operand may be left out, as after the ;; of 1 ;; 10, or may
+  // directly follow, as after the _ of _Integer, so that the scanner does
+  // not read that operand as a second factor.
+  externals: $ => [
+    $.comment,
+    $._string_content,
+    '[',
+    '[[',
+    ']',
+    ']]',
+    $._argument_minus,
+    $._number_trailing_dot,
+    $._operator_symbol,
+    $._juxtaposition,
+    $._optional_operand,
+    $._immediate_operand,
+    $._error_sentinel
   ],
 
-  precedences: $ => [
-    [$.var_rest_pattern, $.var_pattern, $.symbol],
+  conflicts: $ => [
+    [$.span]
   ],
 
-  conflicts: $ => [
-    [$.symbol, $.var_pattern],
-    [$.symbol, $.var_rest_pattern]
+  // Whitespace, newlines included, only separates tokens: an expression
+  // left unfinished at the end of a line, such as `a +`, continues on the
+  // next. Two complete expressions on separate lines are two statements,
+  // since a product by juxtaposition never spans a line. A `\` just before
+  // a newline is accepted as an explicit continuation and dropped like
+  // whitespace.
+  extras: $ => [
+    /\s/,
+    /\\\r?\n/,
+    $.comment
   ],
 
   rules: {
+    // A file is a sequence of expressions, one or more to a line.
     source_file: $ => repeat1($.expression),
 
     expression: $ => choice(
       $.number,
       $.string,
-      prec(3, $.var_rest_pattern),  // Highest priority
-      prec(2, $.var_pattern),       // Medium priority
-      prec(1, $.symbol),            // Lowest priority (catch-all)
+      $.var_rest_pattern,
+      $.blank,
+      $.pattern,
+      $.symbol,
+      $.slot,
+      $.out_reference,
       $.brace_call,
-      $.function_call
+      $.list,
+      $.association,
+      $.function_call,
+      $.application,
+      $.part,
+      $.parenthesized_expression,
+      $.unary_expression,
+      $.not,
+      $.and,
+      $.or,
+      $.comparison,
+      $.factorial,
+      $.derivative,
+      $.binary_expression,
+      $.implicit_multiply,
+      $.rule,
+      $.rule_delayed,
+      $.replace_all,
+      $.replace_repeated,
+      $.function,
+      $.prefix_application,
+      $.postfix_application,
+      $.apply,
+      $.map_apply,
+      $.set,
+      $.set_delayed,
+      $.compound_assignment,
+      $.compound_expression,
+      $.span,
+      $.information,
+      $.pattern_test,
+      $.condition,
+      $.alternatives,
+      $.pattern_bind,
+      $.pattern_default,
+      $.repeated,
+      $.repeated_null,
+      $.optional_pattern,
+      $.composition,
+      $.string_join,
+      $.string_expression,
+      $.infix_application,
+      $.message_name
     ),
 
-    // Comments
-    comment: $ => token(choice(
-      // Semicolon comment
-      /;[^\n]*/,
-      // Double-slash comment
-      /\/\/[^\n]*/,
-      // Block comment
-      /\/\*([^*]|\*[^\/])*\*\//
-    )),
-
-    // Numbers
-    number: $ => token(/-?\d+(\.\d+)?/),
+    // Numbers: 42, 3.14, .5, 1.2e-3, base-n 16^^FF and 2^^101.01, with an
+    // optional precision mark: 1.5`, 1.5`20 or 1.5``20. A trailing dot
+    // belongs to the number, 5. is a real as in the core parser, unless
+    // another dot follows: {1..} is a Repeated 1. The scanner lexes those
+    // reals, since telling 5. from 5.. takes a look past the dot.
+    //
+    // As in the core parser, a `-` written directly before the digits is
+    // part of the literal, so {Add 1 -1} has two arguments and -2^2 is
+    // (-2)^2. After an operand a spaced `-` is still subtraction: 1 - 1,
+    // and in f[1 -1], where no new argument can start, 1 -1 too.
+    number: $ => choice(
+      token(seq(
+        optional('-'),
+        choice(
+          seq(
+            choice(
+              /\d+\^\^[0-9a-zA-Z]+(\.[0-9a-zA-Z]+)?/,
+              /(\d+(\.\d+)?|\.\d+|\d+\.[eE][+-]?\d+)([eE][+-]?\d+)?/
+            ),
+            optional(PRECISION)
+          ),
+          seq(/\d+\./, PRECISION)
+        )
+      )),
+      $._number_trailing_dot
+    ),
 
-    // Strings
+    // Strings: "..." with \" \\ \n \r \t and \uXXXX escapes and named
+    // characters such as \[Alpha]. Strings may span lines; one left open at
+    // the end of the file is an ERROR.
     string: $ => seq(
       '"',
       repeat(choice(
-        // Escaped sequences
-        /\\[nrt"\\]/,
-        // Any character except quotes and backslash
-        /[^"\\]+/
+        $.escape_sequence,
+        $.named_character,
+        $._string_content
       )),
-      '"'
+      token.immediate('"')
     ),
 
-    // Variable rest pattern: name... or name___ or just ... or ___
+    escape_sequence: $ => token.immediate(seq(
+      '\\',
+      choice(/[nrt"\\]/, /u[0-9a-fA-F]{4}/)
+    )),
+
+    // Named characters: \[Alpha], \[Beta] and so on. In a string each is
+    // a named_character child; in a symbol it is part of the symbol token,
+    // like any other letter, so \[Alpha] + \[Beta] is a sum of two symbols
+    // and x\[Prime] is one symbol. The names are not checked against a
+    // list. The other backslash forms of Mathematica, \:03b1 and \.e9
+    // escapes and \!\( \) box forms, are not supported.
+    named_character: $ => token.immediate(NAMED_CHARACTER),
+
+    // Variable rest pattern: name... or just ..., and as in the core
+    // parser name.. or just .. too: {x_ xs..}, {.. {Eff ef_} ..}. The
+    // two-dot form takes a plain name, so x_Integer.. is still Repeated.
     var_rest_pattern: $ => token(choice(
       '...',   // Just dots (wildcard rest)
-      '___',   // Just underscores (wildcard rest)
+      '..',
       /[a-zA-Z][a-zA-Z0-9_]*\.\.\./,  // Name followed by dots
-      /[a-zA-Z][a-zA-Z0-9]*___/  // Name followed by exactly three underscores
+      /[a-zA-Z][a-zA-Z0-9]*\.\./
     )),
 
-    // Variable pattern: name_ or just _
-    var_pattern: $ => token(choice(
-      '_',  // Just underscore (wildcard)
-      /[a-zA-Z][a-zA-Z0-9]*_/  // Name followed by underscore
+    // Blanks: _ (one expression), __ (one or more), ___ (zero or more),
+    // optionally restricted to a head written directly after them: _Integer
+    blank: $ => seq(
+      choice('_', '__', '___'),
+      optional(seq(optional($._immediate_operand), field('type', alias($._immediate_symbol, $.symbol))))
+    ),
+
+    // Named pattern: x_, x__, x___, x_Integer
+    // The blank must touch the name; `x _` is two separate expressions.
+    pattern: $ => seq(
+      field('name', $.symbol),
+      field('blank', alias($._immediate_blank, $.blank))
+    ),
+
+    _immediate_blank: $ => seq(
+      choice(token.immediate('_'), token.immediate('__'), token.immediate('___')),
+      optional(seq(optional($._immediate_operand), field('type', alias($._immediate_symbol, $.symbol))))
+    ),
+
+    // Optional pattern: x_. and x_Integer. take their default when the
+    // argument is left out. The dot must touch the blank, so it never reads
+    // as a decimal point: in x_.5 the .5 is a number, and x_ . y, with
+    // spaces, is an error, since there is no Dot operator. x_.. is a
+    // Repeated x_, the longer token winning.
+    optional_pattern: $ => seq(
+      field('pattern', choice($.pattern, $.blank)),
+      token.immediate('.')
+    ),
+
+    // Symbols are identifier-like: ASCII letters, digits and `$`, plus any
+    // non-ASCII character (so `→` is still a symbol). `_` is reserved for
+    // blanks, so `x_` is a pattern rather than a symbol.
+    //
+    // As in the core parser, a name may be compound, Core/Main or
+    // Eff->Pending, and a keyword starts with a colon, :rule or :guard. So
+    // an operator between two names needs spaces around it: a / b, a - b
+    // and a -> b, since a/b is the symbol a/b. A compound name is one
+    // token; splitting it is left to consumers like contexts are.
+    //
+    // A symbol may be qualified by contexts separated with backticks,
+    // System`Plus, and a leading backtick names a context relative to the
+    // current one, `x. The whole name is one token with no children: the
+    // contexts are part of the symbol's name, and splitting them is left to
+    // consumers. A backtick must be followed by a name, so a stray one is
+    // an error rather than the start of a symbol. After a number a backtick
+    // is the precision mark instead (see number).
+    symbol: $ => token(seq(
+      optional(choice('`', ':')),
+      NAME,
+      repeat(choice(seq('`', NAME), NAME_PART))
     )),
 
-    // Regular symbols - match any non-whitespace, non-delimiter characters
-    // This matches the original parser behavior
-    symbol: $ => token(/[^{}\(\),\s";\n]+/),
+    // Slots stand for the arguments of a pure function: # (the first),
+    // #2 (by position) and #name (by key).
+    slot: $ => token(/#([0-9]+|[a-zA-Z$][a-zA-Z0-9$]*)?/),
+
+    // References to earlier results in a session: % (the last), %% and
+    // %%% (further back) and %5 (result 5). Syma has no modulo operator, so
+    // % only ever starts a reference, and each form is one token: %3 * 2
+    // multiplies result 3 rather than taking 3 modulo something.
+    out_reference: $ => token(/%+|%[0-9]+/),
+
+    // The type of a blank. Its precedence keeps _Integer... a blank followed
+    // by dots rather than _ and a var_rest_pattern Integer....
+    _immediate_symbol: $ => token.immediate(prec(1, seq(optional('`'), NAME, repeat(seq('`', NAME))))),
 
-    // Brace call syntax: {head arg1 arg2 ...}
+    // Brace call syntax: {head arg1 arg2 ...}. Whitespace separates the
+    // arguments, so as in the core parser a `-` with a space before it and
+    // none after starts a new one: {List a -b c} has four arguments, and
+    // {Add 1 -1} two (see number). A spaced `-` is still subtraction:
+    // {Plus a - b} has one argument.
+    //
+    // The head may also be an operator followed by a space, which the core
+    // parser reads as a symbol: {+ a b}, {<= x 1}, {/@ f list}.
     brace_call: $ => seq(
       '{',
-      field('head', $.expression),
-      field('arguments', repeat($.expression)),
+      field('head', choice(
+        $.expression,
+        alias($._operator_head, $.expression)
+      )),
+      field('arguments', repeat(choice(
+        $.expression,
+        alias($._negated_argument, $.expression)
+      ))),
       '}'
     ),
 
-    // Function call syntax: head(arg1, arg2, ...)
-    function_call: $ => prec(2, seq(
-      field('function', choice(
-        $.symbol,
-        alias(/[a-zA-Z_][a-zA-Z0-9_]*/, $.symbol)
+    _operator_head: $ => alias($._operator_symbol, $.symbol),
+
+    _negated_argument: $ => alias($._negation, $.unary_expression),
+
+    _negation: $ => prec(PREC.unary, seq(
+      field('operator', alias($._argument_minus, '-')),
+      field('operand', $.expression)
+    )),
+
+    // List literal: {a, b, c}. The comma is what tells a list apart from a
+    // brace call, so a one-element list needs a trailing one: {a,}. {} is
+    // the empty list.
+    list: $ => seq(
+      '{',
+      optional(seq(
+        $.expression,
+        choice(
+          ',',
+          seq(repeat1(seq(',', $.expression)), optional(','))
+        )
       )),
+      '}'
+    ),
+
+    // Association literal: <|key -> value, ...|>. Entries are rules; a
+    // trailing comma is allowed.
+    association: $ => seq(
+      '<|',
+      optional(seq(
+        $._association_entry,
+        repeat(seq(',', $._association_entry)),
+        optional(',')
+      )),
+      '|>'
+    ),
+
+    _association_entry: $ => choice(
+      $.rule,
+      $.rule_delayed
+    ),
+
+    // Function call syntax: head(arg1, arg2, ...). As in the core parser
+    // the commas may be left out, so Program(.. App(..) ..) has three
+    // arguments, and a negation starts a new one as in a brace call.
+    function_call: $ => prec(2, seq(
+      field('function', $.symbol),
       token.immediate('('),
       field('arguments', optional($._argument_list)),
       ')'
     )),
 
+    // Bracket application: head[arg1, arg2, ...]. The head is any
+    // expression, so curried f[x][y] and (a + b)[x] both work. A trailing
+    // comma is allowed, as in lists; besides matching {a, b,}, it lets an
+    // unfinished `f[x,` recover as an application missing its `]`.
+    application: $ => prec(PREC.call, seq(
+      field('head', $.expression),
+      '[',
+      field('arguments', optional($._bracket_argument_list)),
+      ']'
+    )),
+
+    // Part extraction: list[[i]], m[[i, j]], list[[2 ;; -1]]. `[` never
+    // starts an expression, so `[[` always opens a part. `]]` is only a
+    // token where a part can close, so f[g[x]] still ends with two `]`.
+    part: $ => prec(PREC.call, seq(
+      field('value', $.expression),
+      '[[',
+      field('indices', $._bracket_argument_list),
+      ']]'
+    )),
+
+    parenthesized_expression: $ => seq(
+      '(',
+      $.expression,
+      ')'
+    ),
+
+    // Negation -x and unary plus +x. Both bind looser than ^, as in
+    // Mathematica, so -x^2 is -(x^2), and tighter than * and /. After an
+    // operand a sign is binary: a - -b subtracts -b, and a -b is a - b
+    // except between brace-call arguments (see brace_call).
+    unary_expression: $ => prec(PREC.unary, seq(
+      field('operator', choice('-', '+')),
+      field('operand', $.expression)
+    )),
+
+    // Factorial: n! and the double factorial n!!, which is one operator,
+    // not two factorials; write (n!)! for those. Binds tighter than ^, so
+    // n!^2 is (n!)^2 and 2^n! is 2^(n!).
+    factorial: $ => prec.left(PREC.factorial, seq(
+      field('argument', $.expression),
+      field('operator', choice('!', '!!'))
+    )),
+
+    // Derivative: f' and f''. Each mark is one derivative node, and bracket
+    // application binds looser, so f''[x] applies the second derivative.
+    derivative: $ => prec.left(PREC.derivative, seq(
+      field('function', $.expression),
+      "'"
+    )),
+
+    // Arithmetic: + and - group left, as do * and /; ^ groups right.
+    binary_expression: $ => choice(
+      ...[
+        ['+', PREC.plus],
+        ['-', PREC.plus],
+        ['*', PREC.times],
+        ['/', PREC.times],
+      ].map(([operator, precedence]) => prec.left(precedence, seq(
+        field('left', $.expression),
+        field('operator', operator),
+        field('right', $.expression)
+      ))),
+      prec.right(PREC.power, seq(
+        field('left', $.expression),
+        field('operator', '^'),
+        field('right', $.expression)
+      ))
+    ),
+
+    // Implicit multiplication: 2 x, a b c and 3 (x + 1) are products, with
+    // the precedence of *, so 2 x^2 + 1 is (2 (x^2)) + 1. As in the core
+    // lexer a number ends before a letter, so 2x is 2 x too. The factors
+    // must be on one line, and the second must start with a letter, a digit
+    // or, after a space, a parenthesis: f (x) is a product but f(x) a call,
+    // and f [x] is still the application f[x]. Where whitespace separates
+    // arguments, in brace calls and function calls, it is never a product:
+    // {Times 2 x} has two arguments. The scanner tells those places by
+    // whether a negated argument could start there.
+    implicit_multiply: $ => prec.left(PREC.times, seq(
+      field('left', $.expression),
+      $._juxtaposition,
+      field('right', $.expression)
+    )),
+
+    // Comparisons bind looser than arithmetic and tighter than the logical
+    // operators. A chain nests left like the other binary operators:
+    // a < b <= c is (a < b) <= c.
+    comparison: $ => prec.left(PREC.comparison, seq(
+      field('left', $.expression),
+      field('operator', choice('==', '!=', '<', '<=', '>', '>=')),
+      field('right', $.expression)
+    )),
+
+    // Logical operators: && binds tighter than ||, and ! binds tightest,
+    // like the other prefix operators: !a == b is (!a) == b and !a && b is
+    // (!a) && b. Write !(a == b) to negate a comparison.
+    not: $ => prec(PREC.not, seq(
+      '!',
+      field('operand', $.expression)
+    )),
+
+    and: $ => prec.left(PREC.and, seq(
+      field('left', $.expression),
+      '&&',
+      field('right', $.expression)
+    )),
+
+    or: $ => prec.left(PREC.or, seq(
+      field('left', $.expression),
+      '||',
+      field('right', $.expression)
+    )),
+
+    // Spans: start ;; end and start ;; end ;; step. Any part may be left
+    // out, so ;; 5, 1 ;; and ;; ;; 2 are spans too. A span binds looser
+    // than arithmetic, so 1 ;; n + 1 ends at n + 1. The three-part form
+    // is preferred, so a ;; b ;; c is one span rather than a ;; (b ;; c).
+    // Where expressions sit side by side, as in {f a ;; b}, `;; b` could
+    // also be a span of its own; the start is taken when there is one. A
+    // line that starts with `;;` is a comment, so a span without a start
+    // must follow something on its line: list[[;; 5]].
+    span: $ => choice(
+      prec.right(PREC.span, seq(
+        optional(prec.dynamic(1, field('start', $.expression))),
+        ';;',
+        optional(seq(optional($._optional_operand), field('end', $.expression)))
+      )),
+      prec.right(PREC.span + 1, seq(
+        optional(prec.dynamic(1, field('start', $.expression))),
+        ';;',
+        optional(seq(optional($._optional_operand), field('end', $.expression))),
+        ';;',
+        field('step', $.expression)
+      ))
+    ),
+
+    // Replacement rules: lhs -> rhs (Rule) and lhs :> rhs (RuleDelayed).
+    // Both group right and bind looser than arithmetic.
+    rule: $ => prec.right(PREC.rule, seq(
+      field('left', $.expression),
+      '->',
+      field('right', $.expression)
+    )),
+
+    rule_delayed: $ => prec.right(PREC.rule, seq(
+      field('left', $.expression),
+      ':>',
+      field('right', $.expression)
+    )),
+
+    // Pattern tests and conditions: x_ ? NumberQ matches what NumberQ
+    // accepts, and patt /; test only matches where test holds. `?` binds
+    // tighter than anything but calls and postfix operators; `/;` binds
+    // looser than comparisons and tighter than rules, so
+    // x_ ? NumberQ /; x > 0 -> x is ((x_ ? NumberQ) /; (x > 0)) -> x.
+    pattern_test: $ => prec.left(PREC.pattern_test, seq(
+      field('pattern', $.expression),
+      '?',
+      field('test', $.expression)
+    )),
+
+    condition: $ => prec.left(PREC.condition, seq(
+      field('pattern', $.expression),
+      '/;',
+      field('condition', $.expression)
+    )),
+
+    // Alternatives: a | b | c matches any of a, b and c. It binds tighter
+    // than `:` and `/;`, so x : a | b names the whole alternative.
+    alternatives: $ => prec.left(PREC.alternatives, seq(
+      field('left', $.expression),
+      '|',
+      field('right', $.expression)
+    )),
+
+    // Repeated patterns: p.. matches one or more p and p... zero or more.
+    // Following Mathematica's table they bind looser than arithmetic and
+    // tighter than |, so (a + b).. and a | (b)...
+    //
+    // name.. and name... are already var_rest_patterns, the rest patterns
+    // the core parser knows, and so is x_Integer...; standalone dots after a
+    // space are the wildcard. So p.. and p... are only Repeated when the
+    // dots follow a non-name operand directly: x_Integer.., {1..},
+    // _Integer..., f[x]..., (a | b)....
+    repeated: $ => prec.left(PREC.repeated, seq(
+      field('pattern', $.expression),
+      alias(token.immediate(prec(1, '..')), '..')
+    )),
+
+    repeated_null: $ => prec.left(PREC.repeated, seq(
+      field('pattern', $.expression),
+      alias(token.immediate(prec(1, '...')), '...')
+    )),
+
+    // `:` names a pattern when its left side is a symbol, x : _Integer, and
+    // gives a pattern a default otherwise, n_ : 1. It groups left, so
+    // x : _ : 0 is a named pattern with a default.
+    pattern_bind: $ => prec.left(PREC.pattern_bind, seq(
+      field('name', $.symbol),
+      ':',
+      field('pattern', $.expression)
+    )),
+
+    pattern_default: $ => prec.left(PREC.pattern_bind, seq(
+      field('pattern', $.expression),
+      ':',
+      field('default', $.expression)
+    )),
+
+    // Replacement: expr /. rules (ReplaceAll) and expr //. rules
+    // (ReplaceRepeated). Both group left and bind looser than rules, so
+    // `expr /. a -> b` applies the rule `a -> b`.
+    replace_all: $ => prec.left(PREC.replace, seq(
+      field('left', $.expression),
+      '/.',
+      field('right', $.expression)
+    )),
+
+    replace_repeated: $ => prec.left(PREC.replace, seq(
+      field('left', $.expression),
+      '//.',
+      field('right', $.expression)
+    )),
+
+    // Pure function: body &. It binds looser than rules and replacement,
+    // so `#1 + #2 &` and `# -> 1 &` close over the whole expression.
+    function: $ => prec.left(PREC.function, seq(
+      field('body', $.expression),
+      '&'
+    )),
+
+    // Operator forms of application: f @ x is f[x], x // f is f[x],
+    // f @@ list replaces the head of list with f and f @@@ list does that
+    // at level 1. `@` binds tighter than arithmetic, `//` looser than
+    // everything but compound expressions. A `//` that starts a line
+    // begins a line comment, as in the core parser, and so does one
+    // mid-line unless a single function follows it, x // f or
+    // x // Map[g] &. A one-word comment such as x // TODO therefore reads
+    // as a pipeline.
+    prefix_application: $ => prec.right(PREC.prefix, seq(
+      field('function', $.expression),
+      '@',
+      field('argument', $.expression)
+    )),
+
+    postfix_application: $ => prec.left(PREC.postfix, seq(
+      field('argument', $.expression),
+      '//',
+      field('function', $.expression)
+    )),
+
+    apply: $ => prec.right(PREC.apply, seq(
+      field('function', $.expression),
+      '@@',
+      field('argument', $.expression)
+    )),
+
+    map_apply: $ => prec.right(PREC.apply, seq(
+      field('function', $.expression),
+      '@@@',
+      field('argument', $.expression)
+    )),
+
+    // String operators. a <> b joins strings and binds tighter than
+    // arithmetic, so "n = " <> n + 1 adds to the joined string. a ~~ b is a
+    // string pattern, binding looser than | and tighter than /;, so
+    // x__ ~~ ".syma" | ".sy" matches either extension. Both group left.
+    string_join: $ => prec.left(PREC.string_join, seq(
+      field('left', $.expression),
+      '<>',
+      field('right', $.expression)
+    )),
+
+    string_expression: $ => prec.left(PREC.string_expression, seq(
+      field('left', $.expression),
+      '~~',
+      field('right', $.expression)
+    )),
+
+    // Tilde infix: a ~f~ b is f[a, b]. The function is a symbol, and
+    // chains group left, a ~f~ b ~g~ c being g[f[a, b], c]. It binds looser
+    // than arithmetic and tighter than comparisons. `~~` is one token, so
+    // it is always a string_expression and never two tildes.
+    infix_application: $ => prec.left(PREC.infix, seq(
+      field('left', $.expression),
+      '~',
+      field('function', $.symbol),
+      '~',
+      field('right', $.expression)
+    )),
+
+    // Composition: f @* g is the function that applies g, then f. It
+    // groups right, binds tighter than @@ and looser than @, so
+    // (f @* g)[x] needs its parentheses. RightComposition, f /* g, is not
+    // supported: /* opens a block comment in Syma.
+    composition: $ => prec.right(PREC.composition, seq(
+      field('left', $.expression),
+      '@*',
+      field('right', $.expression)
+    )),
+
+    // Assignment: lhs = rhs (Set) and lhs := rhs (SetDelayed). They bind
+    // looser than everything else, so `f[x_] := x^2` and `g := x -> x`
+    // assign the whole right side, and they group right: a = b = c.
+    set: $ => prec.right(PREC.assign, seq(
+      field('left', $.expression),
+      '=',
+      field('right', $.expression)
+    )),
+
+    set_delayed: $ => prec.right(PREC.assign, seq(
+      field('left', $.expression),
+      ':=',
+      field('right', $.expression)
+    )),
+
+    // In-place arithmetic: x += 1, x -= 1, x *= 2, x /= 2.
+    compound_assignment: $ => prec.right(PREC.compound_assign, seq(
+      field('left', $.expression),
+      field('operator', choice('+=', '-=', '*=', '/=')),
+      field('right', $.expression)
+    )),
+
+    // Compound expression: a; b runs a and then b. `;` binds loosest of
+    // all and groups right, so a; b; c is a; (b; c). A trailing `;` leaves
+    // the right side out: `a;` evaluates a and returns Null. As in the core
+    // parser, `;` also starts a line comment: always at the start of a
+    // line, and mid-line when it has a space on both sides, as in
+    // x_    ; a pattern. So the operator must touch its left operand.
+    compound_expression: $ => prec.right(PREC.compound, seq(
+      field('left', $.expression),
+      ';',
+      optional(seq(optional($._optional_operand), field('right', $.expression)))
+    )),
+
+    // Message names: f::usage and f::"custom" tag the symbol f. The head
+    // and tag are a symbol and a symbol or string, not expressions, so
+    // `::` binds tighter than any operator: f::usage = "..." assigns to
+    // the message name.
+    message_name: $ => seq(
+      field('head', $.symbol),
+      '::',
+      field('tag', choice($.symbol, $.string))
+    ),
+
+    // Information: ?Sin and ??Plus look up a symbol, ?"Syma`*" every
+    // symbol matching a string pattern. The operand is a symbol or string,
+    // not an expression. Its precedence is below every other rule, so where
+    // a `?` could also be infix, as in x_?NumberQ, that reading wins.
+    information: $ => prec(PREC.information, seq(
+      field('operator', choice('?', '??')),
+      field('name', choice($.symbol, $.string))
+    )),
+
     _argument_list: $ => seq(
+      $._argument,
+      repeat(seq(optional(','), $._argument))
+    ),
+
+    _argument: $ => choice(
+      $.expression,
+      alias($._negated_argument, $.expression)
+    ),
+
+    _bracket_argument_list: $ => seq(
       $.expression,
-      repeat(seq(',', $.expression))
+      repeat(seq(',', $.expression)),
+      optional(',')
     )
   }
 });
\ No newline at end of file
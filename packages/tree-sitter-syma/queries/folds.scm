@@ -0,0 +1,18 @@
+; Brackets that span lines fold; a construct on a single line has nothing to
+; fold and editors skip it. Only the inner nodes are captured, never their
+; expression wrappers, so no two folds share a range.
+
+[
+  (list)
+  (association)
+  (brace_call)
+  (application)
+  (function_call)
+  (comment)
+] @fold
+
+; A multi-statement body in parentheses: f[x_] := (a; b)
+
+(parenthesized_expression
+  (expression
+    (compound_expression))) @fold
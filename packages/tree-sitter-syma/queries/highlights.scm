@@ -0,0 +1,136 @@
+; Heads in application position are functions. Earlier patterns win, so
+; these come before the catch-all symbol capture below.
+
+(application
+  head: (expression (symbol) @function))
+
+(brace_call
+  head: (expression (symbol) @function))
+
+(function_call
+  function: (symbol) @function)
+
+(prefix_application
+  function: (expression (symbol) @function))
+
+(postfix_application
+  function: (expression (symbol) @function))
+
+(apply
+  function: (expression (symbol) @function))
+
+(map_apply
+  function: (expression (symbol) @function))
+
+(infix_application
+  function: (symbol) @function)
+
+; Message names: the tag in f::usage
+
+(message_name
+  tag: (symbol) @property)
+
+; Patterns
+
+(pattern
+  name: (symbol) @variable.parameter)
+
+(pattern_bind
+  name: (symbol) @variable.parameter)
+
+(blank
+  type: (symbol) @type)
+
+(blank) @variable.parameter
+
+(var_rest_pattern) @variable.parameter
+
+(slot) @variable.parameter
+
+(out_reference) @variable.builtin
+
+(symbol) @variable
+
+; Literals
+
+(number) @number
+
+(string) @string
+
+(escape_sequence) @string.escape
+(named_character) @string.escape
+
+(comment) @comment
+
+; Operators
+
+[
+  "->"
+  ":>"
+  "="
+  ":="
+  "+="
+  "-="
+  "*="
+  "/="
+] @operator
+
+[
+  "+"
+  "-"
+  "*"
+  "/"
+  "^"
+  "/."
+  "//."
+  "&"
+  "@"
+  "//"
+  "@@"
+  "@@@"
+  "@*"
+  "<>"
+  "~~"
+  "~"
+  "!"
+  "!!"
+  "'"
+  "=="
+  "!="
+  "<"
+  "<="
+  ">"
+  ">="
+  "&&"
+  "||"
+  ";;"
+  "?"
+  "??"
+  "/;"
+  "|"
+  ":"
+  "::"
+  "."
+  ".."
+  "..."
+] @operator
+
+; Punctuation
+
+[
+  "("
+  ")"
+  "["
+  "]"
+  "[["
+  "]]"
+  "{"
+  "}"
+  "<|"
+  "|>"
+] @punctuation.bracket
+
+[
+  ","
+  ";"
+] @punctuation.delimiter
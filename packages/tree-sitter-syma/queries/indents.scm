@@ -0,0 +1,32 @@
+; Lines inside a bracketed construct are indented one level; nested brackets
+; that open on the same line still add only one level.
+
+[
+  (application)
+  (part)
+  (function_call)
+  (brace_call)
+  (list)
+  (association)
+  (parenthesized_expression)
+] @indent.begin
+
+; The closing bracket goes back to the level of the line that opened it.
+
+[
+  "]"
+  "]]"
+  ")"
+  "}"
+  "|>"
+] @indent.branch @indent.end
+
+; A right side that continues on the next line, after a trailing -> or :=,
+; is indented one level.
+
+[
+  (rule)
+  (rule_delayed)
+  (set)
+  (set_delayed)
+] @indent.begin
@@ -0,0 +1,30 @@
+; Only strings whose meaning is certain are injected; every other string
+; stays a plain string. The contents of a string are not a node of their
+; own, so the whole literal is captured and #offset! drops the quotes.
+
+; Comments, so that the comment language can mark TODO and FIXME.
+
+((comment) @injection.content
+  (#set! injection.language "comment"))
+
+; The pattern of RegularExpression["a+b*"] is a regular expression. Only
+; a string that is the first argument counts.
+
+((application
+  head: (expression (symbol) @_head)
+  .
+  arguments: (expression (string) @injection.content))
+  (#eq? @_head "RegularExpression")
+  (#offset! @injection.content 0 1 0 -1)
+  (#set! injection.language "regex"))
+
+; Usage messages are Markdown: f::usage = "f[x] gives *x*."
+
+((set
+  left: (expression
+    (message_name
+      tag: (symbol) @_tag))
+  right: (expression (string) @injection.content))
+  (#eq? @_tag "usage")
+  (#offset! @injection.content 0 1 0 -1)
+  (#set! injection.language "markdown"))
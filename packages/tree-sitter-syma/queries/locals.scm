@@ -0,0 +1,24 @@
+; Scopes: a rule or a definition binds its pattern variables for its own
+; right-hand side, and a pure function is a scope of its own.
+
+(rule) @local.scope
+
+(rule_delayed) @local.scope
+
+(set) @local.scope
+
+(set_delayed) @local.scope
+
+(function) @local.scope
+
+; Definitions
+
+(pattern
+  name: (symbol) @local.definition)
+
+(pattern_bind
+  name: (symbol) @local.definition)
+
+; References
+
+(symbol) @local.reference
@@ -0,0 +1,35 @@
+// Package queries embeds the tree-sitter queries of the Syma grammar, so Go
+// code can use them without reading files at run time.
+package queries
+
+import _ "embed"
+
+// Highlights is the syntax highlighting query, highlights.scm.
+//
+//go:embed highlights.scm
+var Highlights string
+
+// Locals is the local-variable query, locals.scm.
+//
+//go:embed locals.scm
+var Locals string
+
+// Tags is the symbol tagging query, tags.scm.
+//
+//go:embed tags.scm
+var Tags string
+
+// Folds is the code folding query, folds.scm.
+//
+//go:embed folds.scm
+var Folds string
+
+// Indents is the auto-indent query, indents.scm.
+//
+//go:embed indents.scm
+var Indents string
+
+// Injections is the language injection query, injections.scm.
+//
+//go:embed injections.scm
+var Injections string
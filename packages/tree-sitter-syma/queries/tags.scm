@@ -0,0 +1,44 @@
+; Definitions: f[x_] := body defines the function f and x = value the
+; variable x. Definitions come first so that the defined name is not also
+; tagged as a call below.
+
+(set_delayed
+  left: (expression
+    (application
+      head: (expression (symbol) @name)))) @definition.function
+
+(set
+  left: (expression
+    (application
+      head: (expression (symbol) @name)))) @definition.function
+
+(set_delayed
+  left: (expression (symbol) @name)) @definition.variable
+
+(set
+  left: (expression (symbol) @name)) @definition.variable
+
+; The same, written out as applications: SetDelayed[f[x_], body] and
+; Set[x, value].
+
+((application
+  head: (expression (symbol) @_head)
+  .
+  arguments: (expression
+    (application
+      head: (expression (symbol) @name)))) @definition.function
+  (#any-of? @_head "Set" "SetDelayed"))
+
+((application
+  head: (expression (symbol) @_head)
+  .
+  arguments: (expression (symbol) @name)) @definition.variable
+  (#any-of? @_head "Set" "SetDelayed"))
+
+; References
+
+(application
+  head: (expression (symbol) @name)) @reference.call
+
+(function_call
+  function: (symbol) @name) @reference.call
@@ -0,0 +1,572 @@
+#include "tree_sitter/alloc.h"
+#include "tree_sitter/array.h"
+#include "tree_sitter/parser.h"
+
+#include <string.h>
+#include <wctype.h>
+
+enum TokenType {
+  COMMENT,
+  STRING_CONTENT,
+  OPEN_BRACKET,
+  OPEN_PART,
+  CLOSE_BRACKET,
+  CLOSE_PART,
+  ARGUMENT_MINUS,
+  NUMBER_TRAILING_DOT,
+  OPERATOR_SYMBOL,
+  JUXTAPOSITION,
+  OPTIONAL_OPERAND,
+  IMMEDIATE_OPERAND,
+  ERROR_SENTINEL,
+};
+
+// The kinds of open bracket on the stack.
+enum Bracket {
+  BRACKET,
+  PART,
+};
+
+// Starts a serialized state that has a comment end; never a Bracket.
+#define COMMENT_MARK 0xFF
+
+// The scanner state is the stack of open brackets, innermost last, and
+// where the last block comment to start its line ended (see the scan
+// function).
+typedef struct {
+  Array(uint8_t) brackets;
+  uint32_t comment_end;
+} Scanner;
+
+static inline void advance(TSLexer *lexer) { lexer->advance(lexer, false); }
+
+static inline void skip(TSLexer *lexer) { lexer->advance(lexer, true); }
+
+// String contents run up to the closing quote or the next escape, which the
+// grammar lexes itself. Whitespace is content here, not a separator.
+static bool scan_string_content(TSLexer *lexer) {
+  bool has_content = false;
+  while (lexer->lookahead != '"' && lexer->lookahead != '\\' && !lexer->eof(lexer)) {
+    advance(lexer);
+    has_content = true;
+  }
+  if (has_content) {
+    lexer->mark_end(lexer);
+    lexer->result_symbol = STRING_CONTENT;
+  }
+  return has_content;
+}
+
+// Skips a string inside a comment, so that a "*)" in it does not close the
+// comment. Returns false at the end of the file.
+static bool skip_string(TSLexer *lexer) {
+  advance(lexer);
+  while (lexer->lookahead != '"') {
+    if (lexer->eof(lexer)) {
+      return false;
+    }
+    if (lexer->lookahead == '\\') {
+      advance(lexer);
+      if (lexer->eof(lexer)) {
+        return false;
+      }
+    }
+    advance(lexer);
+  }
+  advance(lexer);
+  return true;
+}
+
+// (* ... *) with nesting. The opening "(" has been consumed. An unterminated
+// comment is not a token: the parser then sees "(" and "*" and reports an
+// error.
+static bool scan_block_comment(TSLexer *lexer) {
+  if (lexer->lookahead != '*') {
+    return false;
+  }
+  advance(lexer);
+  unsigned depth = 1;
+  for (;;) {
+    if (lexer->eof(lexer)) {
+      return false;
+    }
+    switch (lexer->lookahead) {
+      case '(':
+        advance(lexer);
+        if (lexer->lookahead == '*') {
+          advance(lexer);
+          depth++;
+        }
+        break;
+      case '*':
+        advance(lexer);
+        if (lexer->lookahead == ')') {
+          advance(lexer);
+          if (--depth == 0) {
+            return true;
+          }
+        }
+        break;
+      case '"':
+        if (!skip_string(lexer)) {
+          return false;
+        }
+        break;
+      default:
+        advance(lexer);
+        break;
+    }
+  }
+}
+
+// /* ... */ without nesting. The opening "/" has been consumed.
+static bool scan_c_comment(TSLexer *lexer) {
+  if (lexer->lookahead != '*') {
+    return false;
+  }
+  advance(lexer);
+  for (;;) {
+    if (lexer->eof(lexer)) {
+      return false;
+    }
+    if (lexer->lookahead == '*') {
+      advance(lexer);
+      if (lexer->lookahead == '/') {
+        advance(lexer);
+        return true;
+      }
+    } else {
+      advance(lexer);
+    }
+  }
+}
+
+static inline bool at_line_end(TSLexer *lexer) {
+  return lexer->lookahead == '\n' || lexer->lookahead == '\r' || lexer->eof(lexer);
+}
+
+// Consumes the rest of a line comment, up to but not including the newline.
+static bool scan_line_comment(TSLexer *lexer) {
+  while (!at_line_end(lexer)) {
+    advance(lexer);
+  }
+  return true;
+}
+
+static inline bool is_name_char(int32_t c) {
+  return iswalnum(c) || c == '$' || c == '`' || c == '#' || c >= 0x80;
+}
+
+// Skips a bracketed group and anything nested in it, on the same line.
+// Returns false if the line ends first.
+static bool skip_group(TSLexer *lexer) {
+  unsigned depth = 0;
+  do {
+    if (at_line_end(lexer)) {
+      return false;
+    }
+    switch (lexer->lookahead) {
+      case '[':
+      case '(':
+      case '{':
+        depth++;
+        break;
+      case ']':
+      case ')':
+      case '}':
+        depth--;
+        break;
+      case '"':
+        if (!skip_string(lexer)) {
+          return false;
+        }
+        continue;
+    }
+    advance(lexer);
+  } while (depth > 0);
+  return true;
+}
+
+// Whether the rest of the line after a mid-line "//" is a single pipeline
+// stage: a function name, perhaps with bracketed arguments and a closing
+// "&", up to the end of the line, the next "//" or a closing bracket, as in
+// x // f, x // Map[g] // h or {x // f}. Anything else, such as the words
+// of // LI A0, 7, is taken for a comment.
+static bool is_pipeline_stage(TSLexer *lexer) {
+  while (lexer->lookahead == ' ' || lexer->lookahead == '\t') {
+    advance(lexer);
+  }
+  if (!is_name_char(lexer->lookahead)) {
+    return false;
+  }
+  while (is_name_char(lexer->lookahead)) {
+    advance(lexer);
+  }
+  while (lexer->lookahead == '[') {
+    if (!skip_group(lexer)) {
+      return false;
+    }
+  }
+  while (lexer->lookahead == ' ' || lexer->lookahead == '\t') {
+    advance(lexer);
+  }
+  if (lexer->lookahead == '&') {
+    advance(lexer);
+    while (lexer->lookahead == ' ' || lexer->lookahead == '\t') {
+      advance(lexer);
+    }
+  }
+  switch (lexer->lookahead) {
+    case ')':
+    case ']':
+    case '}':
+    case ',':
+    case ';':
+      return true;
+    case '/':
+      advance(lexer);
+      return lexer->lookahead == '/';
+    default:
+      return at_line_end(lexer);
+  }
+}
+
+// Whether the token being scanned starts its line: it follows a newline, or
+// the skipped whitespace before it began at column 0. width characters of
+// the token have been consumed.
+static inline bool starts_line(TSLexer *lexer, bool newline, uint32_t skipped, uint32_t width) {
+  return newline || lexer->get_column(lexer) == skipped + width;
+}
+
+// A // or /* comment. The first "/" has been consumed.
+static bool scan_slash_comment(TSLexer *lexer, bool newline, uint32_t skipped) {
+  if (lexer->lookahead != '/') {
+    return scan_c_comment(lexer);
+  }
+  advance(lexer);
+  if (starts_line(lexer, newline, skipped, 2)) {
+    return scan_line_comment(lexer);
+  }
+  return lexer->lookahead != '.' && !is_pipeline_stage(lexer) && scan_line_comment(lexer);
+}
+
+// The leading whitespace has been skipped by the caller: skipped characters
+// of it, not counting any up to the last newline, which newline reports.
+//
+// As in the core parser, ; and // start line comments. They are also the
+// compound, span and postfix operators, so they are comments only where an
+// operator cannot be meant: at the start of a line, always; for ;, also
+// after a space and before a space or the end of the line, as in
+// x_    ; a pattern; for //, also mid-line unless a single pipeline stage
+// follows (see is_pipeline_stage). a; b and 1 ;; 10 stay operators, and so
+// does //. everywhere but at the start of a line.
+static bool scan_comment(TSLexer *lexer, bool newline, uint32_t skipped) {
+  bool found = false;
+  switch (lexer->lookahead) {
+    case '(':
+      advance(lexer);
+      found = scan_block_comment(lexer);
+      break;
+    case '/':
+      advance(lexer);
+      found = scan_slash_comment(lexer, newline, skipped);
+      break;
+    case ';':
+      advance(lexer);
+      if (starts_line(lexer, newline, skipped, 1)) {
+        found = scan_line_comment(lexer);
+      } else if (skipped > 0 && (lexer->lookahead == ' ' || lexer->lookahead == '\t' || at_line_end(lexer))) {
+        found = scan_line_comment(lexer);
+      }
+      break;
+  }
+  if (found) {
+    lexer->mark_end(lexer);
+    lexer->result_symbol = COMMENT;
+  }
+  return found;
+}
+
+// "[" or "[[". A "[[" is one token wherever a part may start.
+static bool scan_open(Scanner *scanner, TSLexer *lexer, const bool *valid_symbols) {
+  advance(lexer);
+  lexer->mark_end(lexer);
+  if (lexer->lookahead == '[' && valid_symbols[OPEN_PART]) {
+    advance(lexer);
+    lexer->mark_end(lexer);
+    array_push(&scanner->brackets, PART);
+    lexer->result_symbol = OPEN_PART;
+    return true;
+  }
+  if (valid_symbols[OPEN_BRACKET]) {
+    array_push(&scanner->brackets, BRACKET);
+    lexer->result_symbol = OPEN_BRACKET;
+    return true;
+  }
+  return false;
+}
+
+// "]" or "]]". A "]]" is one token only when the innermost open bracket is
+// a "[[", so the "]]]" in a[[f[x]]] is "]" then "]]". Brackets lost to a
+// truncated serialization count as plain "[".
+static bool scan_close(Scanner *scanner, TSLexer *lexer, const bool *valid_symbols) {
+  uint8_t top = scanner->brackets.size > 0 ? *array_back(&scanner->brackets) : BRACKET;
+  advance(lexer);
+  lexer->mark_end(lexer);
+  if (top == PART && lexer->lookahead == ']' && valid_symbols[CLOSE_PART]) {
+    advance(lexer);
+    lexer->mark_end(lexer);
+    array_pop(&scanner->brackets);
+    lexer->result_symbol = CLOSE_PART;
+    return true;
+  }
+  if (valid_symbols[CLOSE_BRACKET]) {
+    if (scanner->brackets.size > 0) {
+      array_pop(&scanner->brackets);
+    }
+    lexer->result_symbol = CLOSE_BRACKET;
+    return true;
+  }
+  return false;
+}
+
+// A "-" that starts a brace-call argument: one with whitespace before it
+// and an operand right after it, {List a -b}. A "-" before a digit is left
+// to the number token, and "->", "-=" and a spaced "-" to the operators.
+// The "-" has been consumed.
+static bool scan_argument_minus(TSLexer *lexer) {
+  int32_t next = lexer->lookahead;
+  if (iswspace(next) || iswdigit(next) || next == '.' || next == '>' || next == '=' || next == '-' || lexer->eof(lexer)) {
+    return false;
+  }
+  lexer->mark_end(lexer);
+  lexer->result_symbol = ARGUMENT_MINUS;
+  return true;
+}
+
+// A real with a trailing dot, -?\d+. as in 5. or -1., where the dot is
+// followed by nothing the internal number token would take: not a digit, an
+// exponent or a precision mark, and not another dot, so that 1.. is 1 and
+// Repeated. Other numbers are left to the internal lexer. The leading "-",
+// if any, has been consumed.
+static bool scan_number_trailing_dot(TSLexer *lexer) {
+  if (!iswdigit(lexer->lookahead)) {
+    return false;
+  }
+  while (iswdigit(lexer->lookahead)) {
+    advance(lexer);
+  }
+  if (lexer->lookahead != '.') {
+    return false;
+  }
+  advance(lexer);
+  int32_t next = lexer->lookahead;
+  if (iswdigit(next) || next == '.' || next == 'e' || next == 'E' || next == '`') {
+    return false;
+  }
+  lexer->mark_end(lexer);
+  lexer->result_symbol = NUMBER_TRAILING_DOT;
+  return true;
+}
+
+static inline bool is_operator_char(int32_t c) {
+  return c != 0 && c < 0x80 && strchr("+-*/\\^=<>!?@&|~.:[]", c) != NULL;
+}
+
+// An operator written as the head of a brace call, {+ a b}, {<= a b} or
+// {/@ f x}, which the core parser reads as a symbol: a run of operator
+// characters followed by whitespace or the closing brace. What else may
+// start there is left alone: a comment, a negative number, an association
+// and the wildcards .. and ..., and an operator touching its operand, as in
+// {-x}, stays a prefix operator.
+static bool scan_operator_symbol(TSLexer *lexer, const bool *valid_symbols, bool newline, uint32_t skipped) {
+  int32_t first = lexer->lookahead;
+  advance(lexer);
+  if (first == '/' && (lexer->lookahead == '/' || lexer->lookahead == '*')) {
+    if (valid_symbols[COMMENT] && scan_slash_comment(lexer, newline, skipped)) {
+      lexer->mark_end(lexer);
+      lexer->result_symbol = COMMENT;
+      return true;
+    }
+    return false;
+  }
+  if (first == '-' && iswdigit(lexer->lookahead)) {
+    return valid_symbols[NUMBER_TRAILING_DOT] && scan_number_trailing_dot(lexer);
+  }
+  if (first == '<' && lexer->lookahead == '|') {
+    return false;
+  }
+  bool dots = first == '.';
+  while (is_operator_char(lexer->lookahead)) {
+    dots = dots && lexer->lookahead == '.';
+    advance(lexer);
+  }
+  if (dots || !(iswspace(lexer->lookahead) || lexer->lookahead == '}' || lexer->eof(lexer))) {
+    return false;
+  }
+  lexer->mark_end(lexer);
+  lexer->result_symbol = OPERATOR_SYMBOL;
+  return true;
+}
+
+// The empty token between the factors of an implicit product, 2 x. The
+// caller has checked that an expression may end here, that neither an
+// argument nor another operand may start, and that the next factor is
+// on the same line and starts with a letter, a digit or, after a space,
+// "(", since f(x) is a call. "(*" starts a comment instead.
+static bool scan_juxtaposition(TSLexer *lexer, const bool *valid_symbols) {
+  lexer->mark_end(lexer);
+  if (lexer->lookahead == '(') {
+    advance(lexer);
+    if (lexer->lookahead == '*') {
+      if (valid_symbols[COMMENT] && scan_block_comment(lexer)) {
+        lexer->mark_end(lexer);
+        lexer->result_symbol = COMMENT;
+        return true;
+      }
+      return false;
+    }
+  }
+  lexer->result_symbol = JUXTAPOSITION;
+  return true;
+}
+
+void *tree_sitter_syma_external_scanner_create(void) {
+  Scanner *scanner = ts_calloc(1, sizeof(Scanner));
+  array_init(&scanner->brackets);
+  return scanner;
+}
+
+void tree_sitter_syma_external_scanner_destroy(void *payload) {
+  Scanner *scanner = (Scanner *)payload;
+  array_delete(&scanner->brackets);
+  ts_free(scanner);
+}
+
+// One byte per open bracket, after the comment end if there is one, marked
+// by a COMMENT_MARK byte. The state is usually just the brackets, and
+// tree-sitter copies it with every external token, so it is kept short.
+// Past TREE_SITTER_SERIALIZATION_BUFFER_SIZE only the innermost brackets
+// are kept, since those decide the next "]".
+unsigned tree_sitter_syma_external_scanner_serialize(void *payload, char *buffer) {
+  Scanner *scanner = (Scanner *)payload;
+  unsigned offset = 0;
+  if (scanner->comment_end > 0) {
+    buffer[0] = COMMENT_MARK;
+    memcpy(buffer + 1, &scanner->comment_end, sizeof(scanner->comment_end));
+    offset = 1 + sizeof(scanner->comment_end);
+  }
+  unsigned size = scanner->brackets.size;
+  unsigned start = 0;
+  if (size > TREE_SITTER_SERIALIZATION_BUFFER_SIZE - offset) {
+    start = size - (TREE_SITTER_SERIALIZATION_BUFFER_SIZE - offset);
+    size = TREE_SITTER_SERIALIZATION_BUFFER_SIZE - offset;
+  }
+  if (size > 0) {
+    memcpy(buffer + offset, scanner->brackets.contents + start, size);
+  }
+  return offset + size;
+}
+
+void tree_sitter_syma_external_scanner_deserialize(void *payload, const char *buffer, unsigned length) {
+  Scanner *scanner = (Scanner *)payload;
+  array_clear(&scanner->brackets);
+  scanner->comment_end = 0;
+  if (length > 0 && (uint8_t)buffer[0] == COMMENT_MARK) {
+    memcpy(&scanner->comment_end, buffer + 1, sizeof(scanner->comment_end));
+    buffer += 1 + sizeof(scanner->comment_end);
+    length -= 1 + sizeof(scanner->comment_end);
+  }
+  if (length > 0) {
+    array_reserve(&scanner->brackets, length);
+    memcpy(scanner->brackets.contents, buffer, length);
+    scanner->brackets.size = length;
+  }
+}
+
+// The token after the skipped whitespace; newline tells whether it starts a
+// line and skipped how many blanks precede it on its line.
+static bool scan_token(Scanner *scanner, TSLexer *lexer, const bool *valid_symbols, bool newline, uint32_t skipped) {
+  if (valid_symbols[JUXTAPOSITION] && !valid_symbols[ARGUMENT_MINUS] && !valid_symbols[OPTIONAL_OPERAND] &&
+      !(valid_symbols[IMMEDIATE_OPERAND] && skipped == 0) && !valid_symbols[ERROR_SENTINEL] && !newline &&
+      (iswalnum(lexer->lookahead) || lexer->lookahead == '$' || lexer->lookahead >= 0xA0 ||
+       (lexer->lookahead == '(' && skipped > 0))) {
+    return scan_juxtaposition(lexer, valid_symbols);
+  }
+
+  if (valid_symbols[OPERATOR_SYMBOL] && !valid_symbols[ERROR_SENTINEL] && is_operator_char(lexer->lookahead)) {
+    return scan_operator_symbol(lexer, valid_symbols, newline, skipped);
+  }
+
+  switch (lexer->lookahead) {
+    case '[':
+      return scan_open(scanner, lexer, valid_symbols);
+    case ']':
+      return scan_close(scanner, lexer, valid_symbols);
+    case '-':
+      advance(lexer);
+      if (valid_symbols[ARGUMENT_MINUS] && !valid_symbols[ERROR_SENTINEL] && (newline || skipped > 0) &&
+          scan_argument_minus(lexer)) {
+        return true;
+      }
+      return valid_symbols[NUMBER_TRAILING_DOT] && scan_number_trailing_dot(lexer);
+    default:
+      if (iswdigit(lexer->lookahead)) {
+        return valid_symbols[NUMBER_TRAILING_DOT] && scan_number_trailing_dot(lexer);
+      }
+      if (!valid_symbols[COMMENT]) {
+        return false;
+      }
+      return scan_comment(lexer, newline, skipped);
+  }
+}
+
+bool tree_sitter_syma_external_scanner_scan(void *payload, TSLexer *lexer, const bool *valid_symbols) {
+  Scanner *scanner = (Scanner *)payload;
+
+  // During error recovery every symbol is valid; never start string contents
+  // there, or any text could be swallowed as one.
+  if (valid_symbols[STRING_CONTENT] && !valid_symbols[ERROR_SENTINEL]) {
+    return scan_string_content(lexer);
+  }
+
+  // Skip whitespace and line continuations, the extras the internal lexer
+  // would skip, so that a comment or bracket after them is still found. A
+  // backslash that does not end the line is left to the internal lexer.
+  // Line comments and products need to know whether a line starts here.
+  // After a block comment that starts its line, as in "a\n(* c *) b", the
+  // newline was skipped by an earlier call, so the scanner remembers the
+  // column the comment ended at, plus one.
+  bool newline = scanner->comment_end > 0 && lexer->get_column(lexer) + 1 == scanner->comment_end;
+  scanner->comment_end = 0;
+  uint32_t skipped = 0;
+  for (;;) {
+    if (lexer->lookahead == '\n') {
+      skip(lexer);
+      newline = true;
+      skipped = 0;
+    } else if (iswspace(lexer->lookahead)) {
+      skip(lexer);
+      skipped++;
+    } else if (lexer->lookahead == '\\') {
+      skip(lexer);
+      if (lexer->lookahead == '\r') {
+        skip(lexer);
+      }
+      if (lexer->lookahead != '\n') {
+        return false;
+      }
+      skip(lexer);
+      newline = true;
+      skipped = 0;
+    } else {
+      break;
+    }
+  }
+
+  bool found = scan_token(scanner, lexer, valid_symbols, newline, skipped);
+  if (found && newline && lexer->result_symbol == COMMENT) {
+    scanner->comment_end = lexer->get_column(lexer) + 1;
+  }
+  return found;
+}